@@ -0,0 +1,183 @@
+package bytesconv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/bits"
+	"strings"
+)
+
+// The functions in this file only understand ASCII. Bytes >= 0x80 are never
+// case-folded or treated as white space, so multi-byte UTF-8 sequences pass
+// through untouched. Use the bytes package for full Unicode semantics.
+
+const (
+	lo8 = 0x0101010101010101
+	hi8 = 0x8080808080808080
+)
+
+// caseMask returns a word with 0x20 set in every byte of w that lies in the
+// ASCII range [lo, hi], and zero elsewhere. XOR-ing or OR-ing the mask into
+// w flips the case of exactly those bytes.
+//
+// Each byte is handled as a 7-bit value (heptet) so the additions below can
+// never carry into the neighbouring byte; bytes with the high bit set are
+// excluded explicitly.
+func caseMask(w uint64, lo, hi byte) uint64 {
+	heptets := w &^ hi8
+	ge := heptets + (0x80-uint64(lo))*lo8   // high bit set where byte >= lo
+	gt := heptets + (0x80-uint64(hi)-1)*lo8 // high bit set where byte > hi
+	return ((ge &^ gt) &^ w & hi8) >> 2
+}
+
+// ToLower converts ASCII upper-case letters in b to lower case in place and
+// returns b.
+func ToLower(b []byte) []byte {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		w := binary.LittleEndian.Uint64(b[i:])
+		if m := caseMask(w, 'A', 'Z'); m != 0 {
+			binary.LittleEndian.PutUint64(b[i:], w|m)
+		}
+	}
+	for ; i < len(b); i++ {
+		if c := b[i]; 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return b
+}
+
+// ToUpper converts ASCII lower-case letters in b to upper case in place and
+// returns b.
+func ToUpper(b []byte) []byte {
+	i := 0
+	for ; i+8 <= len(b); i += 8 {
+		w := binary.LittleEndian.Uint64(b[i:])
+		if m := caseMask(w, 'a', 'z'); m != 0 {
+			binary.LittleEndian.PutUint64(b[i:], w&^m)
+		}
+	}
+	for ; i < len(b); i++ {
+		if c := b[i]; 'a' <= c && c <= 'z' {
+			b[i] = c - ('a' - 'A')
+		}
+	}
+	return b
+}
+
+// EqualFold reports whether a and b are equal under ASCII case folding.
+// Unlike bytes.EqualFold, non-ASCII bytes must match exactly.
+func EqualFold(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	i := 0
+	for ; i+8 <= len(a); i += 8 {
+		wa := binary.LittleEndian.Uint64(a[i:])
+		wb := binary.LittleEndian.Uint64(b[i:])
+		if wa == wb {
+			continue
+		}
+		if wa|caseMask(wa, 'A', 'Z') != wb|caseMask(wb, 'A', 'Z') {
+			return false
+		}
+	}
+	for ; i < len(a); i++ {
+		ca, cb := a[i], b[i]
+		if ca == cb {
+			continue
+		}
+		if 'A' <= ca && ca <= 'Z' {
+			ca += 'a' - 'A'
+		}
+		if 'A' <= cb && cb <= 'Z' {
+			cb += 'a' - 'A'
+		}
+		if ca != cb {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPrefixFold reports whether s begins with prefix under ASCII case folding.
+func HasPrefixFold(s, prefix []byte) bool {
+	return len(s) >= len(prefix) && EqualFold(s[:len(prefix)], prefix)
+}
+
+// asciiSpace marks the ASCII white space characters recognized by TrimSpace.
+var asciiSpace = [256]bool{'\t': true, '\n': true, '\v': true, '\f': true, '\r': true, ' ': true}
+
+// TrimSpace returns a subslice of b with leading and trailing ASCII white
+// space removed. It never allocates.
+func TrimSpace(b []byte) []byte {
+	start := 0
+	for start < len(b) && asciiSpace[b[start]] {
+		start++
+	}
+	end := len(b)
+	for end > start && asciiSpace[b[end-1]] {
+		end--
+	}
+	return b[start:end]
+}
+
+// IndexByteAny returns the index of the first byte in s that is contained
+// in chars, or -1 if there is none. Unlike bytes.IndexAny, chars is treated
+// as a set of bytes rather than UTF-8 encoded runes.
+func IndexByteAny(s []byte, chars string) int {
+	switch {
+	case len(chars) == 0:
+		return -1
+	case len(chars) == 1:
+		return bytes.IndexByte(s, chars[0])
+	case len(chars) <= maxSWARChars:
+		return indexByteAnySWAR(s, chars)
+	}
+	var set [256]bool
+	for i := 0; i < len(chars); i++ {
+		set[chars[i]] = true
+	}
+	for i, c := range s {
+		if set[c] {
+			return i
+		}
+	}
+	return -1
+}
+
+// maxSWARChars is the largest set size for which testing each character
+// against a whole word beats a table lookup per byte.
+const maxSWARChars = 4
+
+// indexByteAnySWAR implements IndexByteAny for small sets by checking eight
+// bytes of s at a time against every character in chars. Sets smaller than
+// maxSWARChars are padded by repeating their first character.
+func indexByteAnySWAR(s []byte, chars string) int {
+	var rep [maxSWARChars]uint64
+	for i := range rep {
+		c := chars[0]
+		if i < len(chars) {
+			c = chars[i]
+		}
+		rep[i] = uint64(c) * lo8
+	}
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		w := binary.LittleEndian.Uint64(s[i:])
+		x0, x1, x2, x3 := w^rep[0], w^rep[1], w^rep[2], w^rep[3]
+		// The lowest set high bit marks the first zero byte of any x;
+		// higher bits may be false positives and are ignored.
+		found := (x0-lo8)&^x0 | (x1-lo8)&^x1 | (x2-lo8)&^x2 | (x3-lo8)&^x3
+		if found &= hi8; found != 0 {
+			return i + bits.TrailingZeros64(found)/8
+		}
+	}
+	for ; i < len(s); i++ {
+		if strings.IndexByte(chars, s[i]) >= 0 {
+			return i
+		}
+	}
+	return -1
+}
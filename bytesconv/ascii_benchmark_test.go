@@ -0,0 +1,97 @@
+package bytesconv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var (
+	asciiHeader = []byte(strings.Repeat("X-Forwarded-For: 192.168.0.1, ", 8))
+	asciiLower  = bytes.ToLower(asciiHeader)
+	asciiSink   []byte
+	boolSink    bool
+	intSink     int
+)
+
+func BenchmarkToLowerBytes(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(asciiHeader)))
+	for i := 0; i < b.N; i++ {
+		asciiSink = bytes.ToLower(asciiHeader)
+	}
+}
+
+func BenchmarkToLowerSWAR(b *testing.B) {
+	buf := make([]byte, len(asciiHeader))
+	b.ReportAllocs()
+	b.SetBytes(int64(len(asciiHeader)))
+	for i := 0; i < b.N; i++ {
+		copy(buf, asciiHeader)
+		asciiSink = ToLower(buf)
+	}
+}
+
+func BenchmarkToUpperBytes(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(asciiHeader)))
+	for i := 0; i < b.N; i++ {
+		asciiSink = bytes.ToUpper(asciiHeader)
+	}
+}
+
+func BenchmarkToUpperSWAR(b *testing.B) {
+	buf := make([]byte, len(asciiHeader))
+	b.ReportAllocs()
+	b.SetBytes(int64(len(asciiHeader)))
+	for i := 0; i < b.N; i++ {
+		copy(buf, asciiHeader)
+		asciiSink = ToUpper(buf)
+	}
+}
+
+func BenchmarkEqualFoldBytes(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(asciiHeader)))
+	for i := 0; i < b.N; i++ {
+		boolSink = bytes.EqualFold(asciiHeader, asciiLower)
+	}
+}
+
+func BenchmarkEqualFoldSWAR(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(asciiHeader)))
+	for i := 0; i < b.N; i++ {
+		boolSink = EqualFold(asciiHeader, asciiLower)
+	}
+}
+
+var paddedValue = []byte("   \t value with inner spaces \r\n")
+
+func BenchmarkTrimSpaceBytes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		asciiSink = bytes.TrimSpace(paddedValue)
+	}
+}
+
+func BenchmarkTrimSpaceASCII(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		asciiSink = TrimSpace(paddedValue)
+	}
+}
+
+func BenchmarkIndexAnyBytes(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		intSink = bytes.IndexAny(asciiHeader, ";=\"")
+	}
+}
+
+func BenchmarkIndexByteAny(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		intSink = IndexByteAny(asciiHeader, ";=\"")
+	}
+}
@@ -0,0 +1,185 @@
+package bytesconv
+
+import (
+	"bytes"
+	"testing"
+)
+
+// refLower and refUpper are byte-at-a-time ASCII references.
+func refLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+func refUpper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - ('a' - 'A')
+	}
+	return c
+}
+
+func TestToLowerUpperAllBytes(t *testing.T) {
+	// Every byte value in every lane of the word-at-a-time loop, plus the tail.
+	for pos := 0; pos < 9; pos++ {
+		for c := 0; c < 256; c++ {
+			buf := []byte("xY0@[`{~Z")
+			buf[pos] = byte(c)
+			want := make([]byte, len(buf))
+			for i, b := range buf {
+				want[i] = refLower(b)
+			}
+			if got := ToLower(append([]byte(nil), buf...)); !bytes.Equal(got, want) {
+				t.Fatalf("ToLower(%q) = %q, want %q", buf, got, want)
+			}
+			for i, b := range buf {
+				want[i] = refUpper(b)
+			}
+			if got := ToUpper(append([]byte(nil), buf...)); !bytes.Equal(got, want) {
+				t.Fatalf("ToUpper(%q) = %q, want %q", buf, got, want)
+			}
+		}
+	}
+}
+
+func TestToLower(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"Content-Type", "content-type"},
+		{"X-FORWARDED-FOR: 127.0.0.1", "x-forwarded-for: 127.0.0.1"},
+		{"ÄÖÜ Straße", "ÄÖÜ straße"},
+	}
+	for _, tt := range tests {
+		if got := string(ToLower([]byte(tt.in))); got != tt.want {
+			t.Errorf("ToLower(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if got := string(ToUpper([]byte(tt.want))); got != string(bytes.ToUpper([]byte(tt.want))) && isASCII(tt.want) {
+			t.Errorf("ToUpper(%q) = %q", tt.want, got)
+		}
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEqualFold(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"", "", true},
+		{"a", "A", true},
+		{"Content-Length", "content-length", true},
+		{"CONTENT-LENGTH", "content-length", true},
+		{"content-length", "content-lengti", false},
+		{"content-length", "content-lengthx", false},
+		{"@", "`", false},
+		{"[", "{", false},
+		{"Straße", "STRASSE", false},
+		{"é", "É", false},
+		{"ACCEPT-ENCODING-1", "accept-encoding-1", true},
+	}
+	for _, tt := range tests {
+		if got := EqualFold([]byte(tt.a), []byte(tt.b)); got != tt.want {
+			t.Errorf("EqualFold(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestEqualFoldAllBytes(t *testing.T) {
+	for pos := 0; pos < 9; pos++ {
+		for c1 := 0; c1 < 256; c1++ {
+			for _, c2 := range []int{c1, c1 ^ 0x20} {
+				a := []byte("abcdefghi")
+				b := []byte("ABCDEFGHI")
+				a[pos], b[pos] = byte(c1), byte(c2)
+				want := refLower(byte(c1)) == refLower(byte(c2))
+				if got := EqualFold(a, b); got != want {
+					t.Fatalf("EqualFold(%q, %q) = %v, want %v", a, b, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestHasPrefixFold(t *testing.T) {
+	tests := []struct {
+		s, prefix string
+		want      bool
+	}{
+		{"Bearer token", "bearer ", true},
+		{"BEARER token", "bearer ", true},
+		{"Basic abc", "bearer ", false},
+		{"Bear", "bearer ", false},
+		{"anything", "", true},
+	}
+	for _, tt := range tests {
+		if got := HasPrefixFold([]byte(tt.s), []byte(tt.prefix)); got != tt.want {
+			t.Errorf("HasPrefixFold(%q, %q) = %v, want %v", tt.s, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{" \t\r\n value \v\f", "value"},
+		{"no-space", "no-space"},
+		{" keep ", " keep "},
+		{" in side ", "in side"},
+	}
+	for _, tt := range tests {
+		if got := string(TrimSpace([]byte(tt.in))); got != tt.want {
+			t.Errorf("TrimSpace(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIndexByteAny(t *testing.T) {
+	tests := []struct {
+		s, chars string
+		want     int
+	}{
+		{"key=value", "", -1},
+		{"key=value", "=", 3},
+		{"key=value;x", ";=", 3},
+		{"abc", "xyz", -1},
+		{"", ";=", -1},
+		{"a\xffb", "\xff\x00", 1},
+		{"path/to?q", "?#", 7},
+	}
+	for _, tt := range tests {
+		if got := IndexByteAny([]byte(tt.s), tt.chars); got != tt.want {
+			t.Errorf("IndexByteAny(%q, %q) = %d, want %d", tt.s, tt.chars, got, tt.want)
+		}
+	}
+}
+
+func TestIndexByteAnyPositions(t *testing.T) {
+	sets := []string{";=", ";=\"", ";=\",", "abcdef"}
+	for _, chars := range sets {
+		for pos := 0; pos < 17; pos++ {
+			// 0x01 bytes next to the match exercise borrow propagation in
+			// the word-at-a-time zero detection.
+			s := bytes.Repeat([]byte{0x01}, 17)
+			s[pos] = chars[len(chars)-1]
+			if got := IndexByteAny(s, chars); got != pos {
+				t.Errorf("IndexByteAny(%q, %q) = %d, want %d", s, chars, got, pos)
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+//go:build bytesconv_checked
+
+package bytesconv
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+	"weak"
+)
+
+// Checked reports whether the package was built with the bytesconv_checked
+// build tag.
+const Checked = true
+
+// tracked records a byte slice handed out by StrToBytes together with the
+// string it was created from.
+type tracked struct {
+	ptr    weak.Pointer[byte]
+	size   int
+	orig   string
+	caller string
+}
+
+var (
+	trackMu   sync.Mutex
+	trackList []tracked
+	// trackPrune is the list length at which dead entries are dropped.
+	trackPrune = 1024
+)
+
+// StrToBytes returns a copy of s in checked builds. The copy is tracked so
+// Verify can report callers that write to the result, which would corrupt
+// the original string in regular builds.
+func StrToBytes(s string) []byte {
+	b := []byte(s)
+	if len(b) == 0 {
+		return b
+	}
+
+	caller := "unknown"
+	if _, file, line, ok := runtime.Caller(1); ok {
+		caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	trackMu.Lock()
+	defer trackMu.Unlock()
+	if len(trackList) >= trackPrune {
+		pruneLocked()
+		if len(trackList) >= trackPrune/2 {
+			trackPrune *= 2
+		}
+	}
+	trackList = append(trackList, tracked{
+		ptr:    weak.Make(&b[0]),
+		size:   len(b),
+		orig:   s,
+		caller: caller,
+	})
+	return b
+}
+
+// BytesToStr returns a copy of b in checked builds, so later writes to b
+// cannot change the returned string.
+func BytesToStr(b []byte) string {
+	return string(b)
+}
+
+// Verify reports every tracked StrToBytes result whose content no longer
+// matches the string it was created from. Results that have been garbage
+// collected are no longer checked. Each mutation is reported once as a
+// *MutationError; multiple errors are joined with errors.Join.
+func Verify() error {
+	trackMu.Lock()
+	defer trackMu.Unlock()
+
+	var errs []error
+	live := trackList[:0]
+	for _, t := range trackList {
+		p := t.ptr.Value()
+		if p == nil {
+			continue
+		}
+		if cur := unsafe.String(p, t.size); cur != t.orig {
+			errs = append(errs, &MutationError{
+				Caller:   t.caller,
+				Original: t.orig,
+				Current:  strings.Clone(cur),
+			})
+			continue
+		}
+		live = append(live, t)
+	}
+	clear(trackList[len(live):])
+	trackList = live
+	return errors.Join(errs...)
+}
+
+// pruneLocked drops entries whose byte slices have been collected.
+// trackMu must be held.
+func pruneLocked() {
+	live := trackList[:0]
+	for _, t := range trackList {
+		if t.ptr.Value() != nil {
+			live = append(live, t)
+		}
+	}
+	clear(trackList[len(live):])
+	trackList = live
+}
@@ -0,0 +1,48 @@
+//go:build bytesconv_checked
+
+package bytesconv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckedStrToBytesCopies(t *testing.T) {
+	s := "hello"
+	b := StrToBytes(s)
+	b[0] = 'j'
+	if s != "hello" {
+		t.Fatalf("checked StrToBytes must not share memory, string became %q", s)
+	}
+
+	err := Verify()
+	var merr *MutationError
+	if !errors.As(err, &merr) {
+		t.Fatalf("Verify() error = %v, want *MutationError", err)
+	}
+	if merr.Original != "hello" || merr.Current != "jello" {
+		t.Errorf("MutationError = %+v", merr)
+	}
+
+	// A reported mutation is not reported again.
+	if err := Verify(); err != nil {
+		t.Errorf("second Verify() error = %v, want nil", err)
+	}
+}
+
+func TestCheckedUnmodified(t *testing.T) {
+	b := StrToBytes("read only")
+	if err := Verify(); err != nil {
+		t.Errorf("Verify() error = %v, want nil", err)
+	}
+	_ = b[0]
+}
+
+func TestCheckedBytesToStrCopies(t *testing.T) {
+	b := []byte("abc")
+	s := BytesToStr(b)
+	b[0] = 'x'
+	if s != "abc" {
+		t.Errorf("checked BytesToStr must copy, got %q", s)
+	}
+}
@@ -0,0 +1,294 @@
+package bytesconv
+
+import (
+	"bytes"
+	"math"
+	"unicode/utf8"
+)
+
+// Detection is the result of Detect.
+type Detection struct {
+	// Encoding is the most likely encoding, or Unknown.
+	Encoding Encoding
+	// Confidence estimates how likely Encoding is correct, from 0 to 1.
+	// A byte order mark or pure ASCII input yields 1.
+	Confidence float64
+	// BOMLength is the length of the byte order mark at the start of the
+	// input, or 0 if there is none.
+	BOMLength int
+}
+
+var (
+	bomUTF8    = []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE = []byte{0xff, 0xfe}
+	bomUTF16BE = []byte{0xfe, 0xff}
+)
+
+// Detect guesses the encoding of b, which is usually the first few
+// kilobytes of a file or stream.
+//
+// A byte order mark always wins. Otherwise Detect checks for UTF-16 by the
+// position of zero bytes, for UTF-8 by validity, and then scores the legacy
+// encodings Big5, GBK, Shift_JIS, EUC-KR and Windows-1252 by how well the
+// bytes fit each encoding's structure, how many characters fall in its
+// frequently used ranges, and how many of the most common characters of
+// the language appear. The highest score is returned. GB18030 text is
+// reported as GBK, and EUC-JP is not detected.
+//
+// Detection is heuristic: short samples or mixed content can be
+// misidentified, so check Confidence before trusting the result.
+func Detect(b []byte) Detection {
+	switch {
+	case bytes.HasPrefix(b, bomUTF8):
+		return Detection{Encoding: UTF8, Confidence: 1, BOMLength: len(bomUTF8)}
+	case bytes.HasPrefix(b, bomUTF16LE):
+		return Detection{Encoding: UTF16LE, Confidence: 1, BOMLength: len(bomUTF16LE)}
+	case bytes.HasPrefix(b, bomUTF16BE):
+		return Detection{Encoding: UTF16BE, Confidence: 1, BOMLength: len(bomUTF16BE)}
+	}
+	if len(b) == 0 {
+		return Detection{Encoding: UTF8}
+	}
+
+	if d, ok := detectUTF16(b); ok {
+		return d
+	}
+	if bytes.IndexByte(b, 0) >= 0 {
+		// NUL bytes outside UTF-16 indicate binary data.
+		return Detection{Encoding: Unknown}
+	}
+
+	multi, valid := scanUTF8(b)
+	if valid {
+		if multi == 0 {
+			return Detection{Encoding: UTF8, Confidence: 1}
+		}
+		// Legacy multi-byte text is very rarely valid UTF-8, so every
+		// multi-byte sequence halves the chance of a false positive.
+		return Detection{Encoding: UTF8, Confidence: 1 - math.Pow(0.5, float64(multi+1))}
+	}
+
+	best := Detection{Encoding: Unknown}
+	for _, p := range legacyProfiles {
+		if c := p.score(b); c > best.Confidence {
+			best = Detection{Encoding: p.enc, Confidence: c}
+		}
+	}
+	if c := scoreWindows1252(b); c > best.Confidence {
+		best = Detection{Encoding: Windows1252, Confidence: c}
+	}
+	return best
+}
+
+// detectUTF16 recognizes BOM-less UTF-16 from mostly-Latin text, where
+// every other byte is zero.
+func detectUTF16(b []byte) (Detection, bool) {
+	pairs := len(b) / 2
+	if pairs < 2 {
+		return Detection{}, false
+	}
+	var zeroEven, zeroOdd int
+	for i := 0; i+1 < len(b); i += 2 {
+		if b[i] == 0 {
+			zeroEven++
+		}
+		if b[i+1] == 0 {
+			zeroOdd++
+		}
+	}
+	even := float64(zeroEven) / float64(pairs)
+	odd := float64(zeroOdd) / float64(pairs)
+	switch {
+	case odd >= 0.3 && even <= 0.05:
+		return Detection{Encoding: UTF16LE, Confidence: math.Min(0.95, odd+0.2)}, true
+	case even >= 0.3 && odd <= 0.05:
+		return Detection{Encoding: UTF16BE, Confidence: math.Min(0.95, even+0.2)}, true
+	}
+	return Detection{}, false
+}
+
+// scanUTF8 reports whether b is valid UTF-8 and how many multi-byte
+// sequences it contains. A sequence cut off at the end of b is tolerated
+// because samples are usually truncated at an arbitrary position.
+func scanUTF8(b []byte) (multi int, valid bool) {
+	for i := 0; i < len(b); {
+		if b[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size <= 1 {
+			return multi, len(b)-i < utf8.UTFMax && !utf8.FullRune(b[i:])
+		}
+		multi++
+		i += size
+	}
+	return multi, true
+}
+
+// byteRange is an inclusive range of byte values.
+type byteRange struct{ lo, hi byte }
+
+func inRanges(c byte, rs []byteRange) bool {
+	for _, r := range rs {
+		if r.lo <= c && c <= r.hi {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyProfile describes the structure of a double-byte legacy encoding.
+type legacyProfile struct {
+	enc Encoding
+	// single lists non-ASCII bytes that form a character on their own.
+	single []byteRange
+	// lead and trail list the valid bytes of a double-byte character.
+	lead, trail []byteRange
+	// commonLead and commonTrail describe where frequently used
+	// characters and punctuation live.
+	commonLead, commonTrail []byteRange
+	// frequent holds the codes of the most common characters of the
+	// language, filled in by init from frequentChars.
+	frequent map[uint16]bool
+}
+
+// score returns the confidence that b is encoded with p.
+func (p *legacyProfile) score(b []byte) float64 {
+	var chars, invalid, common, frequent int
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c < 0x80:
+			i++
+			continue
+		case inRanges(c, p.single):
+			i++
+			continue
+		case !inRanges(c, p.lead):
+			invalid++
+			i++
+			continue
+		case i+1 == len(b):
+			// Lead byte cut off at the end of the sample.
+			i++
+			continue
+		}
+		t := b[i+1]
+		if !inRanges(t, p.trail) {
+			invalid++
+			i++
+			continue
+		}
+		chars++
+		if inRanges(c, p.commonLead) && inRanges(t, p.commonTrail) {
+			common++
+		}
+		if p.frequent[uint16(c)<<8|uint16(t)] {
+			frequent++
+		}
+		i += 2
+	}
+	if chars == 0 {
+		return 0
+	}
+	valid := float64(chars) / float64(chars+invalid)
+	commonRatio := float64(common) / float64(chars)
+	frequentRatio := math.Min(1, 3*float64(frequent)/float64(chars))
+	return math.Pow(valid, 4) * (0.5 + 0.3*commonRatio + 0.2*frequentRatio)
+}
+
+var legacyProfiles = []*legacyProfile{
+	{
+		enc:         Big5,
+		lead:        []byteRange{{0xa1, 0xf9}},
+		trail:       []byteRange{{0x40, 0x7e}, {0xa1, 0xfe}},
+		commonLead:  []byteRange{{0xa1, 0xa3}, {0xa4, 0xc6}},
+		commonTrail: []byteRange{{0x40, 0x7e}, {0xa1, 0xfe}},
+	},
+	{
+		enc:         GBK,
+		single:      []byteRange{{0x80, 0x80}},
+		lead:        []byteRange{{0x81, 0xfe}},
+		trail:       []byteRange{{0x40, 0x7e}, {0x80, 0xfe}},
+		commonLead:  []byteRange{{0xa1, 0xa3}, {0xb0, 0xd7}},
+		commonTrail: []byteRange{{0xa1, 0xfe}},
+	},
+	{
+		enc:         ShiftJIS,
+		single:      []byteRange{{0xa1, 0xdf}},
+		lead:        []byteRange{{0x81, 0x9f}, {0xe0, 0xfc}},
+		trail:       []byteRange{{0x40, 0x7e}, {0x80, 0xfc}},
+		commonLead:  []byteRange{{0x81, 0x83}, {0x88, 0x9f}},
+		commonTrail: []byteRange{{0x40, 0x7e}, {0x80, 0xfc}},
+	},
+	{
+		enc:         EUCKR,
+		lead:        []byteRange{{0x81, 0xfe}},
+		trail:       []byteRange{{0x41, 0x5a}, {0x61, 0x7a}, {0x81, 0xfe}},
+		commonLead:  []byteRange{{0xa1, 0xa1}, {0xb0, 0xc8}},
+		commonTrail: []byteRange{{0xa1, 0xfe}},
+	},
+}
+
+// frequentChars lists some of the most frequent characters of each language.
+var frequentChars = map[Encoding]string{
+	Big5: "的一是不了人我在有他這中大來上國個到說們為子和你地出道也時年得就那要下以生會自著去之過家學對可她裡後小麼心多天而能好都然沒日於起還發成事只作當想看文無開手十用主行方又如前所本見經頭面公同三已老從動兩長知民樣現分將外但身些與高意進把法此實回二理美點月明其種聲全工己話",
+	GBK:  "的一是不了人我在有他这中大来上国个到说们为子和你地出道也时年得就那要下以生会自着去之过家学对可她里后小么心多天而能好都然没日于起还发成事只作当想看文无开手十用主行方又如前所本见经头面公同三已老从动两长知民样现分将外但身些与高意进把法此实回二理美点月明其种声全工己话",
+	ShiftJIS: "のにはをたがでてとしれさいるかなもこありまっすうよんだくらけ日本人一大年中出事時国者上分行会見生自理方。、" +
+		"ンルトスイクリラタシカテ",
+	EUCKR: "이다의는에하을가고지기로서한자리사어나도으를니수대정시요해여있것들그보게거아라되인우일면원전상내주제",
+}
+
+func init() {
+	for _, p := range legacyProfiles {
+		p.frequent = make(map[uint16]bool)
+		enc, err := p.enc.TextEncoding()
+		if err != nil {
+			continue
+		}
+		e := enc.NewEncoder()
+		for _, r := range frequentChars[p.enc] {
+			out, err := e.String(string(r))
+			if err != nil || len(out) != 2 {
+				continue
+			}
+			p.frequent[uint16(out[0])<<8|uint16(out[1])] = true
+		}
+	}
+}
+
+// scoreWindows1252 returns the confidence that b is Windows-1252 text.
+// Western European text has isolated accented letters between ASCII
+// letters, while double-byte encodings produce runs of high bytes.
+func scoreWindows1252(b []byte) float64 {
+	var high, invalid, letters, isolated int
+	for i, c := range b {
+		if c < 0x80 {
+			continue
+		}
+		high++
+		switch c {
+		case 0x81, 0x8d, 0x8f, 0x90, 0x9d:
+			// Unassigned in Windows-1252.
+			invalid++
+			continue
+		}
+		if c >= 0xc0 && c != 0xd7 && c != 0xf7 {
+			letters++
+		}
+		prevHigh := i > 0 && b[i-1] >= 0x80
+		nextHigh := i+1 < len(b) && b[i+1] >= 0x80
+		if !prevHigh && !nextHigh {
+			isolated++
+		}
+	}
+	if high == 0 {
+		return 0
+	}
+	valid := float64(high-invalid) / float64(high)
+	n := float64(high)
+	score := math.Pow(valid, 4) * (0.2 + 0.3*float64(letters)/n + 0.5*float64(isolated)/n)
+	// A single-byte guess is never as certain as a structural match.
+	return math.Min(score, 0.9)
+}
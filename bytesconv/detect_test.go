@@ -0,0 +1,122 @@
+package bytesconv
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Sample texts used by the detection and transcoding tests.
+const (
+	textTraditional = "中文測試資料，你好世界。這是一份從舊系統匯出的客戶名單，包含姓名、地址與電話。"
+	textSimplified  = "这是一个简体中文的测试，我们需要从旧的系统中导出数据，并且转换成新的格式。"
+	textJapanese    = "これは日本語のテストです。古いシステムからデータを出力して、新しい形式に変換します。"
+	textKorean      = "이것은 한국어 테스트입니다. 오래된 시스템에서 데이터를 내보내고 새로운 형식으로 변환합니다."
+	textWestern     = "Café au lait, crème brûlée and a naïve résumé from São Paulo."
+)
+
+func encodeSample(t *testing.T, tr transform.Transformer, s string) []byte {
+	t.Helper()
+	out, _, err := transform.Bytes(tr, []byte(s))
+	if err != nil {
+		t.Fatalf("encoding sample: %v", err)
+	}
+	return out
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		want    Encoding
+		bom     int
+		minConf float64
+	}{
+		{"empty", nil, UTF8, 0, 0},
+		{"ascii", []byte("id,name,email\n1,foo,foo@example.com\n"), UTF8, 0, 1},
+		{"utf-8", []byte(textTraditional), UTF8, 0, 0.99},
+		{"utf-8 bom", append([]byte{0xef, 0xbb, 0xbf}, "abc"...), UTF8, 3, 1},
+		{"utf-16le bom", []byte{0xff, 0xfe, 'a', 0}, UTF16LE, 2, 1},
+		{"utf-16be bom", []byte{0xfe, 0xff, 0, 'a'}, UTF16BE, 2, 1},
+		{
+			"utf-16le",
+			encodeSample(t, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder(), "name,amount\nAlice,10\n"),
+			UTF16LE, 0, 0.9,
+		},
+		{
+			"utf-16be",
+			encodeSample(t, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewEncoder(), "name,amount\nAlice,10\n"),
+			UTF16BE, 0, 0.9,
+		},
+		{"big5", encodeSample(t, traditionalchinese.Big5.NewEncoder(), textTraditional), Big5, 0, 0.7},
+		{"gbk", encodeSample(t, simplifiedchinese.GBK.NewEncoder(), textSimplified), GBK, 0, 0.7},
+		{"shift_jis", encodeSample(t, japanese.ShiftJIS.NewEncoder(), textJapanese), ShiftJIS, 0, 0.7},
+		{"euc-kr", encodeSample(t, korean.EUCKR.NewEncoder(), textKorean), EUCKR, 0, 0.7},
+		{"windows-1252", encodeSample(t, charmap.Windows1252.NewEncoder(), textWestern), Windows1252, 0, 0.7},
+		{"binary", []byte{0x89, 'P', 'N', 'G', 0, 0, 0, 0x0d, 0x49, 0x48, 0x44, 0x52, 0x01}, Unknown, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.input)
+			if got.Encoding != tt.want || got.BOMLength != tt.bom || got.Confidence < tt.minConf {
+				t.Errorf("Detect() = %+v (%s), want %s with bom %d and confidence >= %v",
+					got, got.Encoding, tt.want, tt.bom, tt.minConf)
+			}
+			if got.Confidence < 0 || got.Confidence > 1 {
+				t.Errorf("Confidence = %v out of range", got.Confidence)
+			}
+		})
+	}
+}
+
+func TestDetectTruncatedSample(t *testing.T) {
+	// Samples cut in the middle of a character must still be recognized.
+	utf8Text := []byte(strings.Repeat(textSimplified, 4))
+	if got := Detect(utf8Text[:len(utf8Text)-1]); got.Encoding != UTF8 {
+		t.Errorf("Detect(truncated UTF-8) = %s, want UTF-8", got.Encoding)
+	}
+	big5 := encodeSample(t, traditionalchinese.Big5.NewEncoder(), textTraditional)
+	if got := Detect(big5[:len(big5)-1]); got.Encoding != Big5 {
+		t.Errorf("Detect(truncated Big5) = %s, want Big5", got.Encoding)
+	}
+}
+
+func TestParseEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		want Encoding
+	}{
+		{"utf-8", UTF8},
+		{"UTF8", UTF8},
+		{" Big5 ", Big5},
+		{"cp936", GBK},
+		{"Shift_JIS", ShiftJIS},
+		{"sjis", ShiftJIS},
+		{"EUC-KR", EUCKR},
+		{"windows-1252", Windows1252},
+		{"gb18030", GB18030},
+		{"EUC-JP", EUCJP},
+	}
+	for _, tt := range tests {
+		got, err := ParseEncoding(tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseEncoding(%q) = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+		if got.String() == "unknown" {
+			t.Errorf("%v.String() = unknown", got)
+		}
+	}
+	if _, err := ParseEncoding("ebcdic"); err != ErrUnknownEncoding {
+		t.Errorf("ParseEncoding(ebcdic) error = %v, want %v", err, ErrUnknownEncoding)
+	}
+	if got := Encoding(99).String(); got != "unknown" {
+		t.Errorf("Encoding(99).String() = %q", got)
+	}
+}
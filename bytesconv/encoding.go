@@ -0,0 +1,136 @@
+package bytesconv
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// ErrUnknownEncoding is returned for an Encoding value or name that is not
+// supported.
+var ErrUnknownEncoding = errors.New("bytesconv: unknown encoding")
+
+// Encoding identifies a character encoding supported by the transcoding
+// readers and writers.
+type Encoding int
+
+const (
+	// Unknown is returned by Detect when no encoding could be identified.
+	Unknown Encoding = iota
+	// UTF8 is UTF-8. Plain ASCII is reported as UTF8.
+	UTF8
+	// UTF16LE is little-endian UTF-16.
+	UTF16LE
+	// UTF16BE is big-endian UTF-16.
+	UTF16BE
+	// Big5 is the Traditional Chinese Big5 encoding.
+	Big5
+	// GBK is the Simplified Chinese GBK encoding (code page 936).
+	GBK
+	// ShiftJIS is the Japanese Shift_JIS encoding.
+	ShiftJIS
+	// EUCKR is the Korean EUC-KR encoding (code page 949).
+	EUCKR
+	// Windows1252 is the Western European Windows code page 1252.
+	Windows1252
+	// GB18030 is the Simplified Chinese GB18030 encoding, a superset of GBK
+	// that covers all of Unicode.
+	GB18030
+	// EUCJP is the Japanese EUC-JP encoding.
+	EUCJP
+)
+
+var encodingNames = [...]string{
+	Unknown:     "unknown",
+	UTF8:        "UTF-8",
+	UTF16LE:     "UTF-16LE",
+	UTF16BE:     "UTF-16BE",
+	Big5:        "Big5",
+	GBK:         "GBK",
+	ShiftJIS:    "Shift_JIS",
+	EUCKR:       "EUC-KR",
+	Windows1252: "windows-1252",
+	GB18030:     "GB18030",
+	EUCJP:       "EUC-JP",
+}
+
+// encodingAliases maps lower-case names to encodings for ParseEncoding.
+var encodingAliases = map[string]Encoding{
+	"utf-8":        UTF8,
+	"utf8":         UTF8,
+	"utf-16le":     UTF16LE,
+	"utf16le":      UTF16LE,
+	"utf-16be":     UTF16BE,
+	"utf16be":      UTF16BE,
+	"big5":         Big5,
+	"big-5":        Big5,
+	"cp950":        Big5,
+	"gbk":          GBK,
+	"cp936":        GBK,
+	"shift_jis":    ShiftJIS,
+	"shift-jis":    ShiftJIS,
+	"sjis":         ShiftJIS,
+	"euc-kr":       EUCKR,
+	"euckr":        EUCKR,
+	"cp949":        EUCKR,
+	"windows-1252": Windows1252,
+	"cp1252":       Windows1252,
+	"latin1":       Windows1252,
+	"gb18030":      GB18030,
+	"euc-jp":       EUCJP,
+	"eucjp":        EUCJP,
+}
+
+// String returns the canonical name of the encoding.
+func (e Encoding) String() string {
+	if e < 0 || int(e) >= len(encodingNames) {
+		return "unknown"
+	}
+	return encodingNames[e]
+}
+
+// ParseEncoding returns the Encoding for a name such as "big5", "Shift_JIS"
+// or "cp1252". Matching is case-insensitive.
+func ParseEncoding(name string) (Encoding, error) {
+	if e, ok := encodingAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return e, nil
+	}
+	return Unknown, ErrUnknownEncoding
+}
+
+// TextEncoding returns the golang.org/x/text implementation of e. Its
+// decoder replaces invalid input with U+FFFD and its encoder fails on
+// characters e cannot represent.
+func (e Encoding) TextEncoding() (encoding.Encoding, error) {
+	switch e {
+	case UTF8:
+		return unicode.UTF8, nil
+	case UTF16LE:
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), nil
+	case UTF16BE:
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), nil
+	case Big5:
+		return traditionalchinese.Big5, nil
+	case GBK:
+		return simplifiedchinese.GBK, nil
+	case ShiftJIS:
+		return japanese.ShiftJIS, nil
+	case EUCKR:
+		return korean.EUCKR, nil
+	case Windows1252:
+		return charmap.Windows1252, nil
+	case GB18030:
+		return simplifiedchinese.GB18030, nil
+	case EUCJP:
+		return japanese.EUCJP, nil
+	case Unknown:
+	}
+	return nil, ErrUnknownEncoding
+}
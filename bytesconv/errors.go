@@ -0,0 +1,34 @@
+package bytesconv
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrSizeMismatch is returned when a byte slice length is not a multiple
+	// of the element size of the requested type.
+	ErrSizeMismatch = errors.New("bytesconv: byte length is not a multiple of element size")
+	// ErrMisaligned is returned when a byte slice does not start at an address
+	// suitably aligned for the requested element type.
+	ErrMisaligned = errors.New("bytesconv: byte slice is not aligned for element type")
+)
+
+// MutationError reports that a byte slice returned by StrToBytes was written
+// to after the conversion. It is only produced by Verify in builds using the
+// bytesconv_checked build tag.
+type MutationError struct {
+	// Caller is the file:line that called StrToBytes.
+	Caller string
+	// Original is the string that was converted.
+	Original string
+	// Current is the content of the returned byte slice at verification time.
+	Current string
+}
+
+func (e *MutationError) Error() string {
+	return fmt.Sprintf(
+		"bytesconv: result of StrToBytes at %s was mutated: %q became %q",
+		e.Caller, e.Original, e.Current,
+	)
+}
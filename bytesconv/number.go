@@ -0,0 +1,268 @@
+package bytesconv
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+var (
+	// ErrSyntax indicates that a value does not have the right syntax for
+	// the target type. It is the same value as strconv.ErrSyntax, so either
+	// can be used with errors.Is.
+	ErrSyntax = strconv.ErrSyntax
+	// ErrRange indicates that a value is out of range for the target type.
+	// It is the same value as strconv.ErrRange.
+	ErrRange = strconv.ErrRange
+	// ErrBase indicates an invalid base argument.
+	ErrBase = errors.New("invalid base")
+	// ErrBitSize indicates an invalid bit size argument.
+	ErrBitSize = errors.New("invalid bit size")
+)
+
+// NumError records a failed conversion. The input is copied so the error
+// stays valid when the parsed buffer is reused.
+type NumError struct {
+	Func string // the failing function (ParseInt, ParseUint, ParseFloat, ParseBool)
+	Num  string // the input
+	Err  error  // the reason the conversion failed (ErrSyntax, ErrRange, etc.)
+}
+
+func (e *NumError) Error() string {
+	return "bytesconv." + e.Func + ": parsing " + strconv.Quote(e.Num) + ": " + e.Err.Error()
+}
+
+func (e *NumError) Unwrap() error { return e.Err }
+
+func numError(fn string, b []byte, err error) *NumError {
+	return &NumError{Func: fn, Num: string(b), Err: err}
+}
+
+// lower returns the lower-case form of an ASCII letter. The result is only
+// meaningful for letters; it is used for range checks that exclude others.
+func lower(c byte) byte {
+	return c | ('x' - 'X')
+}
+
+// ParseBool returns the boolean value represented by b. It accepts the same
+// values as strconv.ParseBool: 1, t, T, TRUE, true, True, 0, f, F, FALSE,
+// false, False. Any other value returns an error wrapping ErrSyntax.
+func ParseBool(b []byte) (bool, error) {
+	switch BytesToStr(b) {
+	case "1", "t", "T", "true", "TRUE", "True":
+		return true, nil
+	case "0", "f", "F", "false", "FALSE", "False":
+		return false, nil
+	}
+	return false, numError("ParseBool", b, ErrSyntax)
+}
+
+// ParseUint is like ParseInt but for unsigned numbers. A sign prefix is not
+// permitted.
+func ParseUint(b []byte, base, bitSize int) (uint64, error) {
+	n, err := parseUint(b, base, bitSize)
+	if err != nil {
+		return n, numError("ParseUint", b, err)
+	}
+	return n, nil
+}
+
+// ParseInt interprets b in the given base (0, 2 to 36) and bit size
+// (0 to 64) and returns the corresponding value, following the rules of
+// strconv.ParseInt: with base 0 the base is implied by the prefix ("0b",
+// "0o", "0x" or "0") and underscores may separate digits.
+//
+// Errors are of type *NumError. Out of range values return ErrRange and
+// the maximum magnitude value of the appropriate bitSize and sign.
+func ParseInt(b []byte, base, bitSize int) (int64, error) {
+	const fn = "ParseInt"
+	if len(b) == 0 {
+		return 0, numError(fn, b, ErrSyntax)
+	}
+
+	digits := b
+	neg := false
+	switch b[0] {
+	case '+':
+		digits = b[1:]
+	case '-':
+		digits = b[1:]
+		neg = true
+	}
+
+	un, err := parseUint(digits, base, bitSize)
+	if err != nil && !errors.Is(err, ErrRange) {
+		return 0, numError(fn, b, err)
+	}
+
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	}
+	cutoff := uint64(1) << uint(bitSize-1)
+	if !neg && un >= cutoff {
+		return int64(cutoff - 1), numError(fn, b, ErrRange) // #nosec G115 -- cutoff-1 fits in int64
+	}
+	if neg && un > cutoff {
+		return -int64(cutoff-1) - 1, numError(fn, b, ErrRange) // #nosec G115 -- cutoff-1 fits in int64
+	}
+	n := int64(un) // #nosec G115 -- range validated above
+	if neg {
+		n = -n
+	}
+	return n, nil
+}
+
+// parseUint implements ParseUint and the magnitude part of ParseInt. It
+// returns a bare sentinel error so the callers can attach their own name.
+func parseUint(b []byte, base, bitSize int) (uint64, error) {
+	if len(b) == 0 {
+		return 0, ErrSyntax
+	}
+
+	base0 := base == 0
+	digits := b
+	switch {
+	case 2 <= base && base <= 36:
+	case base == 0:
+		base = 10
+		if b[0] == '0' {
+			switch {
+			case len(b) >= 3 && lower(b[1]) == 'b':
+				base, digits = 2, b[2:]
+			case len(b) >= 3 && lower(b[1]) == 'o':
+				base, digits = 8, b[2:]
+			case len(b) >= 3 && lower(b[1]) == 'x':
+				base, digits = 16, b[2:]
+			default:
+				base, digits = 8, b[1:]
+			}
+		}
+	default:
+		return 0, ErrBase
+	}
+
+	if bitSize == 0 {
+		bitSize = strconv.IntSize
+	} else if bitSize < 0 || bitSize > 64 {
+		return 0, ErrBitSize
+	}
+
+	// cutoff is the smallest number such that cutoff*base > MaxUint64.
+	cutoff := uint64(math.MaxUint64)/uint64(base) + 1
+	maxVal := uint64(1)<<uint(bitSize) - 1
+
+	underscores := false
+	var n uint64
+	for _, c := range digits {
+		var d byte
+		switch {
+		case c == '_' && base0:
+			underscores = true
+			continue
+		case '0' <= c && c <= '9':
+			d = c - '0'
+		case 'a' <= lower(c) && lower(c) <= 'z':
+			d = lower(c) - 'a' + 10
+		default:
+			return 0, ErrSyntax
+		}
+		if d >= byte(base) {
+			return 0, ErrSyntax
+		}
+		if n >= cutoff {
+			// n*base overflows
+			return maxVal, ErrRange
+		}
+		n *= uint64(base)
+		n1 := n + uint64(d)
+		if n1 < n || n1 > maxVal {
+			// n+d overflows
+			return maxVal, ErrRange
+		}
+		n = n1
+	}
+
+	if underscores && !underscoreOK(b) {
+		return 0, ErrSyntax
+	}
+	return n, nil
+}
+
+// underscoreOK reports whether the underscores in b are allowed, using the
+// same rules as the Go number literal syntax: underscores may only appear
+// between digits or between a base prefix and a digit.
+func underscoreOK(b []byte) bool {
+	// saw tracks the last character (class) we saw:
+	// ^ for beginning of number,
+	// 0 for a digit or base prefix,
+	// _ for an underscore,
+	// ! for none of the above.
+	saw := byte('^')
+	i := 0
+
+	hex := false
+	if len(b) >= 2 && b[0] == '0' && (lower(b[1]) == 'b' || lower(b[1]) == 'o' || lower(b[1]) == 'x') {
+		i = 2
+		saw = '0'
+		hex = lower(b[1]) == 'x'
+	}
+
+	for ; i < len(b); i++ {
+		if '0' <= b[i] && b[i] <= '9' || hex && 'a' <= lower(b[i]) && lower(b[i]) <= 'f' {
+			saw = '0'
+			continue
+		}
+		if b[i] == '_' {
+			if saw != '0' {
+				return false
+			}
+			saw = '_'
+			continue
+		}
+		if saw == '_' {
+			return false
+		}
+		saw = '!'
+	}
+	return saw != '_'
+}
+
+// ParseFloat converts b to a floating-point number with the precision given
+// by bitSize (32 or 64), accepting the same syntax as strconv.ParseFloat.
+// The input is parsed through a zero-copy string view, so no allocation
+// happens unless an error is returned. Errors are of type *NumError.
+func ParseFloat(b []byte, bitSize int) (float64, error) {
+	f, err := strconv.ParseFloat(BytesToStr(b), bitSize)
+	if err != nil {
+		var ne *strconv.NumError
+		if errors.As(err, &ne) {
+			err = ne.Err
+		}
+		return f, numError("ParseFloat", b, err)
+	}
+	return f, nil
+}
+
+// AppendBool appends "true" or "false" to dst and returns the extended buffer.
+func AppendBool(dst []byte, v bool) []byte {
+	return strconv.AppendBool(dst, v)
+}
+
+// AppendInt appends the string form of i in the given base to dst and
+// returns the extended buffer.
+func AppendInt(dst []byte, i int64, base int) []byte {
+	return strconv.AppendInt(dst, i, base)
+}
+
+// AppendUint appends the string form of i in the given base to dst and
+// returns the extended buffer.
+func AppendUint(dst []byte, i uint64, base int) []byte {
+	return strconv.AppendUint(dst, i, base)
+}
+
+// AppendFloat appends the string form of f to dst and returns the extended
+// buffer. The fmt, prec and bitSize arguments have the same meaning as for
+// strconv.FormatFloat.
+func AppendFloat(dst []byte, f float64, fmt byte, prec, bitSize int) []byte {
+	return strconv.AppendFloat(dst, f, fmt, prec, bitSize)
+}
@@ -0,0 +1,176 @@
+package bytesconv
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+var intInputs = []string{
+	"", "0", "-0", "+0", "1", "-1", "+1", "42", "-42",
+	"127", "128", "-128", "-129", "255", "256",
+	"32767", "32768", "-32768", "-32769",
+	"2147483647", "2147483648", "-2147483648", "-2147483649",
+	"9223372036854775807", "9223372036854775808",
+	"-9223372036854775808", "-9223372036854775809",
+	"18446744073709551615", "18446744073709551616",
+	"99999999999999999999999",
+	"0x1f", "0X1F", "-0x80", "0b101", "0o17", "017", "08", "0x", "0b", "0_1",
+	"1_000", "1__000", "_1000", "1000_", "0x_1f", "0x1_f",
+	"12a", "a12", " 1", "1 ", "--1", "+-1", "-", "+", "1.0", "1e3",
+}
+
+func TestParseIntMatchesStrconv(t *testing.T) {
+	for _, base := range []int{0, 2, 8, 10, 16, 36} {
+		for _, bitSize := range []int{0, 8, 16, 32, 64} {
+			for _, in := range intInputs {
+				want, wantErr := strconv.ParseInt(in, base, bitSize)
+				got, err := ParseInt([]byte(in), base, bitSize)
+				if got != want || (err == nil) != (wantErr == nil) {
+					t.Fatalf("ParseInt(%q, %d, %d) = %d, %v; strconv = %d, %v",
+						in, base, bitSize, got, err, want, wantErr)
+				}
+				if wantErr != nil && !errors.Is(err, wantErr.(*strconv.NumError).Err) {
+					t.Fatalf("ParseInt(%q, %d, %d) error = %v, want %v", in, base, bitSize, err, wantErr)
+				}
+			}
+		}
+	}
+}
+
+func TestParseUintMatchesStrconv(t *testing.T) {
+	for _, base := range []int{0, 2, 8, 10, 16, 36} {
+		for _, bitSize := range []int{0, 8, 16, 32, 64} {
+			for _, in := range intInputs {
+				want, wantErr := strconv.ParseUint(in, base, bitSize)
+				got, err := ParseUint([]byte(in), base, bitSize)
+				if got != want || (err == nil) != (wantErr == nil) {
+					t.Fatalf("ParseUint(%q, %d, %d) = %d, %v; strconv = %d, %v",
+						in, base, bitSize, got, err, want, wantErr)
+				}
+				if wantErr != nil && !errors.Is(err, wantErr.(*strconv.NumError).Err) {
+					t.Fatalf("ParseUint(%q, %d, %d) error = %v, want %v", in, base, bitSize, err, wantErr)
+				}
+			}
+		}
+	}
+}
+
+func TestParseIntErrors(t *testing.T) {
+	tests := []struct {
+		in      string
+		base    int
+		bitSize int
+		want    error
+	}{
+		{"12x", 10, 64, ErrSyntax},
+		{"300", 10, 8, ErrRange},
+		{"1", 1, 64, ErrBase},
+		{"1", 37, 64, ErrBase},
+		{"1", 10, 65, ErrBitSize},
+	}
+	for _, tt := range tests {
+		_, err := ParseInt([]byte(tt.in), tt.base, tt.bitSize)
+		var ne *NumError
+		if !errors.As(err, &ne) {
+			t.Fatalf("ParseInt(%q) error = %v, want *NumError", tt.in, err)
+		}
+		if ne.Func != "ParseInt" || ne.Num != tt.in || !errors.Is(err, tt.want) {
+			t.Errorf("ParseInt(%q) error = %#v, want %v", tt.in, ne, tt.want)
+		}
+	}
+}
+
+func TestNumErrorCopiesInput(t *testing.T) {
+	buf := []byte("abc")
+	_, err := ParseInt(buf, 10, 64)
+	buf[0] = 'x'
+	var ne *NumError
+	if !errors.As(err, &ne) || ne.Num != "abc" {
+		t.Errorf("NumError.Num = %q, want %q", ne.Num, "abc")
+	}
+	if got := err.Error(); got != `bytesconv.ParseInt: parsing "abc": invalid syntax` {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	tests := []struct {
+		in      string
+		bitSize int
+		want    float64
+		err     error
+	}{
+		{"3.14", 64, 3.14, nil},
+		{"-0.5e3", 64, -500, nil},
+		{"1e400", 64, math.Inf(1), ErrRange},
+		{"0x1p-2", 64, 0.25, nil},
+		{"16777217", 32, 16777216, nil},
+		{"1.2.3", 64, 0, ErrSyntax},
+		{"", 64, 0, ErrSyntax},
+	}
+	for _, tt := range tests {
+		got, err := ParseFloat([]byte(tt.in), tt.bitSize)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("ParseFloat(%q, %d) = %v, %v, want %v, %v", tt.in, tt.bitSize, got, err, tt.want, tt.err)
+		}
+		if err != nil {
+			var ne *NumError
+			if !errors.As(err, &ne) || ne.Func != "ParseFloat" {
+				t.Errorf("ParseFloat(%q) error = %#v, want *NumError", tt.in, err)
+			}
+		}
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	for _, in := range []string{"1", "t", "T", "true", "TRUE", "True", "0", "f", "F", "false", "FALSE", "False", "", "yes", "tRUE"} {
+		want, wantErr := strconv.ParseBool(in)
+		got, err := ParseBool([]byte(in))
+		if got != want || (err == nil) != (wantErr == nil) {
+			t.Errorf("ParseBool(%q) = %v, %v; strconv = %v, %v", in, got, err, want, wantErr)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	b := []byte("n=")
+	b = AppendInt(b, -42, 10)
+	b = append(b, ' ')
+	b = AppendUint(b, 255, 16)
+	b = append(b, ' ')
+	b = AppendFloat(b, 1.5, 'f', 2, 64)
+	b = append(b, ' ')
+	b = AppendBool(b, true)
+	if got := string(b); got != "n=-42 ff 1.50 true" {
+		t.Errorf("append = %q", got)
+	}
+}
+
+var (
+	numberInput = []byte("-9223372036854775807")
+	int64Sink   int64
+)
+
+func BenchmarkParseIntStrconv(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		int64Sink, _ = strconv.ParseInt(string(numberInput), 10, 64)
+	}
+}
+
+func BenchmarkParseInt(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		int64Sink, _ = ParseInt(numberInput, 10, 64)
+	}
+}
+
+func BenchmarkParseIntError(b *testing.B) {
+	bad := []byte("12345x")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		int64Sink, _ = ParseInt(bad, 10, 64)
+	}
+}
@@ -0,0 +1,190 @@
+package bytesconv
+
+import (
+	"math/bits"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+)
+
+const (
+	// minClassShift is the log2 of the smallest pooled buffer size (64 B).
+	minClassShift = 6
+	// maxClassShift is the log2 of the largest pooled buffer size (1 MiB).
+	maxClassShift = 20
+	numClasses    = maxClassShift - minClassShift + 1
+)
+
+// Pool is a set of sync.Pool instances, one per power-of-two size class
+// from 64 B to 1 MiB. Requests larger than the biggest class are allocated
+// directly and never retained.
+//
+// The zero value is ready to use. A Pool must not be copied after first use.
+type Pool struct {
+	classes [numClasses]sync.Pool
+}
+
+// classIndex returns the index of the smallest size class that can hold n
+// bytes, or -1 if n is larger than the biggest class.
+func classIndex(n int) int {
+	if n <= 1<<minClassShift {
+		return 0
+	}
+	i := bits.Len(uint(n-1)) - minClassShift
+	if i >= numClasses {
+		return -1
+	}
+	return i
+}
+
+// Get returns a buffer of length n. Its capacity is rounded up to the size
+// class and its contents are undefined. A negative n is treated as 0.
+func (p *Pool) Get(n int) []byte {
+	n = max(n, 0)
+	i := classIndex(n)
+	if i < 0 {
+		return make([]byte, n)
+	}
+	size := 1 << (i + minClassShift)
+	if v := p.classes[i].Get(); v != nil {
+		// Buffers are stored as a pointer to their first byte so that Put
+		// does not allocate a slice header.
+		return unsafe.Slice((*byte)(v.(unsafe.Pointer)), size)[:n]
+	}
+	return make([]byte, n, size)
+}
+
+// Put returns b to the pool. Buffers whose capacity is not exactly one of
+// the size classes are dropped. b must not be used after calling Put.
+func (p *Pool) Put(b []byte) {
+	c := cap(b)
+	if c < 1<<minClassShift || c > 1<<maxClassShift || c&(c-1) != 0 {
+		return
+	}
+	i := bits.Len(uint(c)) - 1 - minClassShift
+	p.classes[i].Put(unsafe.Pointer(unsafe.SliceData(b[:1])))
+}
+
+// NewBuilder returns a Builder whose initial buffer of at least size bytes
+// is taken from p.
+func (p *Pool) NewBuilder(size int) *Builder {
+	return &Builder{pool: p, buf: p.Get(size)[:0]}
+}
+
+// Builder accumulates bytes in a pooled buffer and turns them into a string
+// with BytesToStr, without copying.
+//
+// Once String has been called the buffer belongs to the returned string and
+// is never handed back to the pool; Release becomes a no-op for it. Writes
+// after String only append past the end of the returned string, so they do
+// not change it. Call Release when the builder is discarded without calling
+// String so the buffer can be reused.
+//
+// A zero Builder is ready to use and allocates with make.
+type Builder struct {
+	pool *Pool
+	buf  []byte
+	// done is set once buf has been handed to a string by String.
+	done bool
+}
+
+// Len returns the number of accumulated bytes.
+func (b *Builder) Len() int { return len(b.buf) }
+
+// Cap returns the capacity of the underlying buffer.
+func (b *Builder) Cap() int { return cap(b.buf) }
+
+// Bytes returns the accumulated bytes. The slice is only valid until the
+// next write or Release.
+func (b *Builder) Bytes() []byte { return b.buf }
+
+// Grow ensures there is room for another n bytes without reallocating.
+func (b *Builder) Grow(n int) {
+	if n < 0 {
+		panic("bytesconv.Builder.Grow: negative count")
+	}
+	if cap(b.buf)-len(b.buf) < n {
+		b.grow(n)
+	}
+}
+
+func (b *Builder) grow(n int) {
+	want := 2*cap(b.buf) + n
+	var buf []byte
+	if b.pool != nil {
+		buf = b.pool.Get(want)[:len(b.buf)]
+	} else {
+		buf = make([]byte, len(b.buf), want)
+	}
+	copy(buf, b.buf)
+	if b.pool != nil && !b.done {
+		b.pool.Put(b.buf)
+	}
+	b.buf = buf
+	b.done = false
+}
+
+// Write appends p to the buffer. It always returns len(p), nil.
+func (b *Builder) Write(p []byte) (int, error) {
+	if cap(b.buf)-len(b.buf) < len(p) {
+		b.grow(len(p))
+	}
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteString appends s to the buffer. It always returns len(s), nil.
+func (b *Builder) WriteString(s string) (int, error) {
+	if cap(b.buf)-len(b.buf) < len(s) {
+		b.grow(len(s))
+	}
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// WriteByte appends c to the buffer. It always returns nil.
+func (b *Builder) WriteByte(c byte) error {
+	if cap(b.buf)-len(b.buf) < 1 {
+		b.grow(1)
+	}
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of r to the buffer.
+// It returns the number of bytes written and a nil error.
+func (b *Builder) WriteRune(r rune) (int, error) {
+	if cap(b.buf)-len(b.buf) < utf8.UTFMax {
+		b.grow(utf8.UTFMax)
+	}
+	n := len(b.buf)
+	b.buf = utf8.AppendRune(b.buf, r)
+	return len(b.buf) - n, nil
+}
+
+// String returns the accumulated bytes as a string without copying and
+// finalizes the current buffer.
+func (b *Builder) String() string {
+	b.done = true
+	return BytesToStr(b.buf)
+}
+
+// Reset empties the builder. A finalized buffer is dropped rather than
+// reused, so strings returned by String stay intact.
+func (b *Builder) Reset() {
+	if b.done {
+		b.buf = nil
+		b.done = false
+		return
+	}
+	b.buf = b.buf[:0]
+}
+
+// Release returns an unfinalized buffer to the pool and empties the builder.
+func (b *Builder) Release() {
+	if b.pool != nil && !b.done {
+		b.pool.Put(b.buf)
+	}
+	b.buf = nil
+	b.done = false
+}
@@ -0,0 +1,157 @@
+package bytesconv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassIndex(t *testing.T) {
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{0, 0},
+		{1, 0},
+		{64, 0},
+		{65, 1},
+		{128, 1},
+		{4096, 6},
+		{4097, 7},
+		{1 << 20, numClasses - 1},
+		{1<<20 + 1, -1},
+	}
+	for _, tt := range tests {
+		if got := classIndex(tt.n); got != tt.want {
+			t.Errorf("classIndex(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPoolGetPut(t *testing.T) {
+	var p Pool
+
+	b := p.Get(100)
+	if len(b) != 100 || cap(b) != 128 {
+		t.Fatalf("Get(100) len=%d cap=%d, want len=100 cap=128", len(b), cap(b))
+	}
+	p.Put(b)
+
+	b = p.Get(10)
+	if len(b) != 10 || cap(b) < 10 {
+		t.Fatalf("Get(10) len=%d cap=%d", len(b), cap(b))
+	}
+
+	if b := p.Get(-1); len(b) != 0 {
+		t.Fatalf("Get(-1) len=%d, want 0", len(b))
+	}
+
+	big := p.Get(2 << 20)
+	if len(big) != 2<<20 {
+		t.Fatalf("Get(2MiB) len=%d", len(big))
+	}
+	// Oversized and odd-sized buffers are dropped without panicking.
+	p.Put(big)
+	p.Put(make([]byte, 100))
+	p.Put(nil)
+}
+
+func TestBuilder(t *testing.T) {
+	var p Pool
+	b := p.NewBuilder(8)
+	_, _ = b.WriteString("hello")
+	_ = b.WriteByte(' ')
+	_, _ = b.Write([]byte("世界"))
+	_, _ = b.WriteRune('!')
+	if b.Len() != len("hello 世界!") {
+		t.Errorf("Len() = %d", b.Len())
+	}
+
+	s := b.String()
+	if s != "hello 世界!" {
+		t.Fatalf("String() = %q", s)
+	}
+
+	// Writing after String must not change the finalized string.
+	_, _ = b.WriteString(strings.Repeat("x", 200))
+	if s != "hello 世界!" {
+		t.Errorf("finalized string changed to %q", s)
+	}
+
+	// Reset after String must not hand the finalized buffer out again.
+	b.Reset()
+	_, _ = b.WriteString("HELLO")
+	if s != "hello 世界!" {
+		t.Errorf("finalized string changed after Reset to %q", s)
+	}
+	b.Release()
+	if b.Len() != 0 {
+		t.Errorf("Len() after Release = %d", b.Len())
+	}
+}
+
+func TestBuilderZeroValue(t *testing.T) {
+	var b Builder
+	for i := 0; i < 100; i++ {
+		_ = b.WriteByte('a' + byte(i%26))
+	}
+	if got := b.String(); len(got) != 100 || got[:3] != "abc" {
+		t.Errorf("String() = %q", got)
+	}
+	b.Release()
+}
+
+func TestBuilderGrowNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Grow(-1) did not panic")
+		}
+	}()
+	var b Builder
+	b.Grow(-1)
+}
+
+var poolSink []byte
+
+func BenchmarkPoolMake(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := make([]byte, 4096)
+		poolSink = buf
+	}
+}
+
+func BenchmarkPoolGetPut(b *testing.B) {
+	var p Pool
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := p.Get(4096)
+		poolSink = buf
+		p.Put(buf)
+	}
+}
+
+var builderSink string
+
+func BenchmarkBuilderStrings(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var sb strings.Builder
+		for j := 0; j < 64; j++ {
+			sb.WriteString("segment-")
+		}
+		builderSink = sb.String()
+	}
+}
+
+func BenchmarkBuilderPooled(b *testing.B) {
+	var p Pool
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sb := p.NewBuilder(512)
+		for j := 0; j < 64; j++ {
+			_, _ = sb.WriteString("segment-")
+		}
+		builderSink = string(sb.Bytes())
+		sb.Release()
+	}
+}
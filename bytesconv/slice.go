@@ -0,0 +1,71 @@
+package bytesconv
+
+import "unsafe"
+
+// Number is the set of fixed-size numeric types whose in-memory
+// representation can be viewed directly as bytes.
+// Platform-sized types (int, uint, uintptr) are intentionally excluded.
+type Number interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 |
+		~complex64 | ~complex128
+}
+
+// SliceToBytes returns the bytes backing s without copying.
+// The result uses the host byte order and shares memory with s.
+func SliceToBytes[T Number](s []T) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	size := int(unsafe.Sizeof(s[0]))
+	return unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(s))), len(s)*size)
+}
+
+// BytesToSlice reinterprets b as a slice of T without copying.
+// It returns ErrSizeMismatch if len(b) is not a multiple of the size of T,
+// and ErrMisaligned if b does not start at an address aligned for T.
+// The result uses the host byte order and shares memory with b.
+func BytesToSlice[T Number](b []byte) ([]T, error) {
+	var zero T
+	size := int(unsafe.Sizeof(zero))
+	if len(b)%size != 0 {
+		return nil, ErrSizeMismatch
+	}
+	if len(b) == 0 {
+		return nil, nil
+	}
+	ptr := unsafe.Pointer(unsafe.SliceData(b))
+	if uintptr(ptr)%unsafe.Alignof(zero) != 0 {
+		return nil, ErrMisaligned
+	}
+	return unsafe.Slice((*T)(ptr), len(b)/size), nil
+}
+
+// StringsToBytes converts each string in ss with StrToBytes.
+// Only the outer slice is allocated; the inner slices share memory with
+// the original strings and must not be modified.
+func StringsToBytes(ss []string) [][]byte {
+	if ss == nil {
+		return nil
+	}
+	bs := make([][]byte, len(ss))
+	for i, s := range ss {
+		bs[i] = StrToBytes(s)
+	}
+	return bs
+}
+
+// BytesToStrings converts each byte slice in bs with BytesToStr.
+// Only the outer slice is allocated; the strings share memory with the
+// original byte slices, which must not be modified afterwards.
+func BytesToStrings(bs [][]byte) []string {
+	if bs == nil {
+		return nil
+	}
+	ss := make([]string, len(bs))
+	for i, b := range bs {
+		ss[i] = BytesToStr(b)
+	}
+	return ss
+}
@@ -0,0 +1,96 @@
+package bytesconv
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestSliceToBytes(t *testing.T) {
+	u32 := []uint32{1, 0xdeadbeef}
+	b := SliceToBytes(u32)
+	if len(b) != 8 {
+		t.Fatalf("SliceToBytes() len = %d, want 8", len(b))
+	}
+	if got := binary.NativeEndian.Uint32(b[4:]); got != 0xdeadbeef {
+		t.Errorf("SliceToBytes() second element = %#x, want %#x", got, 0xdeadbeef)
+	}
+
+	// The result must share memory with the input.
+	u32[0] = 7
+	if got := binary.NativeEndian.Uint32(b); got != 7 {
+		t.Errorf("SliceToBytes() does not share memory, got %d", got)
+	}
+
+	f64 := []float64{math.Pi}
+	if got := math.Float64frombits(binary.NativeEndian.Uint64(SliceToBytes(f64))); got != math.Pi {
+		t.Errorf("SliceToBytes(float64) = %v, want %v", got, math.Pi)
+	}
+
+	if got := SliceToBytes([]int16(nil)); got != nil {
+		t.Errorf("SliceToBytes(nil) = %v, want nil", got)
+	}
+}
+
+func TestBytesToSlice(t *testing.T) {
+	src := []int64{-1, 0, math.MaxInt64}
+	got, err := BytesToSlice[int64](SliceToBytes(src))
+	if err != nil {
+		t.Fatalf("BytesToSlice() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, src) {
+		t.Errorf("BytesToSlice() = %v, want %v", got, src)
+	}
+
+	type celsius float32
+	temps, err := BytesToSlice[celsius](SliceToBytes([]float32{21.5, -3}))
+	if err != nil {
+		t.Fatalf("BytesToSlice(named) error = %v", err)
+	}
+	if !reflect.DeepEqual(temps, []celsius{21.5, -3}) {
+		t.Errorf("BytesToSlice(named) = %v", temps)
+	}
+
+	empty, err := BytesToSlice[uint16](nil)
+	if err != nil || empty != nil {
+		t.Errorf("BytesToSlice(nil) = %v, %v, want nil, nil", empty, err)
+	}
+}
+
+func TestBytesToSlice_Errors(t *testing.T) {
+	if _, err := BytesToSlice[uint32](make([]byte, 6)); !errors.Is(err, ErrSizeMismatch) {
+		t.Errorf("BytesToSlice(6 bytes) error = %v, want %v", err, ErrSizeMismatch)
+	}
+
+	// Allocate as uint64 so the backing array is 8-byte aligned, then
+	// offset by one byte to force misalignment.
+	buf := SliceToBytes(make([]uint64, 2))
+	if uintptr(unsafe.Pointer(&buf[1]))%unsafe.Alignof(uint32(0)) == 0 {
+		t.Skip("platform does not require alignment")
+	}
+	if _, err := BytesToSlice[uint32](buf[1:5]); !errors.Is(err, ErrMisaligned) {
+		t.Errorf("BytesToSlice(misaligned) error = %v, want %v", err, ErrMisaligned)
+	}
+}
+
+func TestStringsToBytesAndBack(t *testing.T) {
+	ss := []string{"", "hello", "世界"}
+	bs := StringsToBytes(ss)
+	if len(bs) != len(ss) {
+		t.Fatalf("StringsToBytes() len = %d, want %d", len(bs), len(ss))
+	}
+	for i := range ss {
+		if string(bs[i]) != ss[i] {
+			t.Errorf("StringsToBytes()[%d] = %q, want %q", i, bs[i], ss[i])
+		}
+	}
+	if got := BytesToStrings(bs); !reflect.DeepEqual(got, ss) {
+		t.Errorf("BytesToStrings() = %q, want %q", got, ss)
+	}
+	if StringsToBytes(nil) != nil || BytesToStrings(nil) != nil {
+		t.Error("nil input should return nil")
+	}
+}
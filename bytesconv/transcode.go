@@ -0,0 +1,287 @@
+package bytesconv
+
+import (
+	"errors"
+	"io"
+
+	xunicode "golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const (
+	// chunkSize is the size of the pooled buffers used by Reader and Writer.
+	chunkSize = 32 << 10
+	// sniffSize is how much of the input NewDetectReader inspects.
+	sniffSize = 8 << 10
+)
+
+var (
+	errClosed                = errors.New("bytesconv: use of closed transcoder")
+	errInconsistentByteCount = errors.New("bytesconv: inconsistent byte count returned")
+)
+
+// chunkPool provides the buffers of Reader and Writer.
+var chunkPool Pool
+
+// decoder returns a transformer from e to UTF-8. A byte order mark at the
+// start of the input overrides e and is removed.
+func decoder(e Encoding) (transform.Transformer, error) {
+	enc, err := e.TextEncoding()
+	if err != nil {
+		return nil, err
+	}
+	return xunicode.BOMOverride(enc.NewDecoder()), nil
+}
+
+// Reader decodes a stream from a given encoding into UTF-8. Input is
+// processed in fixed-size chunks held in pooled buffers, so memory use does
+// not depend on the size of the stream.
+//
+// Call Close to return the buffers to the pool; it does not close the
+// underlying reader.
+type Reader struct {
+	r io.Reader
+	t transform.Transformer
+
+	src, dst   []byte
+	src0, src1 int
+	dst0, dst1 int
+
+	// err is the sticky error from r, including io.EOF.
+	err error
+	// transformComplete is set once t has consumed all input or failed.
+	transformComplete bool
+	closed            bool
+}
+
+// NewReader returns a Reader that decodes r from enc into UTF-8.
+func NewReader(r io.Reader, enc Encoding) (*Reader, error) {
+	t, err := decoder(enc)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{r: r, t: t}, nil
+}
+
+// NewDetectReader inspects the first few kilobytes of r with Detect and
+// returns a Reader that decodes r from the detected encoding into UTF-8.
+// If no encoding can be identified the input is decoded as UTF-8, with
+// invalid bytes replaced by U+FFFD. The sniffed bytes are not lost; they are
+// the first bytes the Reader decodes.
+func NewDetectReader(r io.Reader) (*Reader, Detection, error) {
+	tr := &Reader{r: r}
+	tr.init()
+	n, err := io.ReadFull(r, tr.src[:sniffSize])
+	tr.src1 = n
+	switch err {
+	case nil:
+	case io.EOF, io.ErrUnexpectedEOF:
+		tr.err = io.EOF
+	default:
+		tr.Close()
+		return nil, Detection{}, err
+	}
+
+	d := Detect(tr.src[:n])
+	enc := d.Encoding
+	if enc == Unknown {
+		enc = UTF8
+	}
+	if tr.t, err = decoder(enc); err != nil {
+		tr.Close()
+		return nil, Detection{}, err
+	}
+	return tr, d, nil
+}
+
+func (r *Reader) init() {
+	if r.src == nil {
+		r.src = chunkPool.Get(chunkSize)
+		r.dst = chunkPool.Get(chunkSize)
+	}
+}
+
+// Read implements io.Reader.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, errClosed
+	}
+	r.init()
+	for {
+		// Copy out any decoded bytes first.
+		if r.dst0 != r.dst1 {
+			n := copy(p, r.dst[r.dst0:r.dst1])
+			r.dst0 += n
+			if r.dst0 == r.dst1 && r.transformComplete {
+				return n, r.err
+			}
+			return n, nil
+		}
+		if r.transformComplete {
+			return 0, r.err
+		}
+
+		// Decode buffered input, or flush the decoder once the underlying
+		// reader has returned an error.
+		if r.src0 != r.src1 || r.err != nil {
+			var n int
+			var err error
+			r.dst0 = 0
+			r.dst1, n, err = r.t.Transform(r.dst, r.src[r.src0:r.src1], r.err == io.EOF)
+			r.src0 += n
+
+			switch {
+			case err == nil:
+				if r.src0 != r.src1 {
+					r.err = errInconsistentByteCount
+				}
+				r.transformComplete = r.err != nil
+				continue
+			case errors.Is(err, transform.ErrShortDst) && (r.dst1 != 0 || n != 0):
+				// dst is full; hand it out and continue.
+				continue
+			case errors.Is(err, transform.ErrShortSrc) && r.src1-r.src0 != len(r.src) && r.err == nil:
+				// An incomplete character; read more input below.
+			default:
+				r.transformComplete = true
+				// A read error other than io.EOF takes precedence.
+				if r.err == nil || r.err == io.EOF {
+					r.err = err
+				}
+				continue
+			}
+		}
+
+		// Move the unconsumed input to the front and read more.
+		if r.src0 != 0 {
+			r.src0, r.src1 = 0, copy(r.src, r.src[r.src0:r.src1])
+		}
+		var n int
+		n, r.err = r.r.Read(r.src[r.src1:])
+		r.src1 += n
+	}
+}
+
+// Close returns the buffers to the pool. It does not close the underlying
+// reader. Reading after Close returns an error.
+func (r *Reader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.src != nil {
+		chunkPool.Put(r.src)
+		chunkPool.Put(r.dst)
+		r.src, r.dst = nil, nil
+	}
+	return nil
+}
+
+// Writer encodes UTF-8 input into a given encoding and writes the result to
+// an underlying writer, using pooled chunk buffers.
+//
+// Characters that cannot be represented in the target encoding make Write
+// fail. Errors are sticky: after a failed Write, buffered input is dropped
+// and every later Write and Close returns the same error. Close must be called to flush any buffered partial character and to
+// return the buffers to the pool; it does not close the underlying writer.
+type Writer struct {
+	w io.Writer
+	t transform.Transformer
+
+	src, dst []byte
+	// n is the number of buffered, not yet encoded bytes in src.
+	n      int
+	err    error
+	closed bool
+}
+
+// NewWriter returns a Writer that encodes UTF-8 into enc and writes to w.
+// No byte order mark is written.
+func NewWriter(w io.Writer, enc Encoding) (*Writer, error) {
+	e, err := enc.TextEncoding()
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, t: e.NewEncoder()}, nil
+}
+
+// Write implements io.Writer. On error it reports how many bytes of p were
+// encoded and written before the failure.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errClosed
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	if w.src == nil {
+		w.src = chunkPool.Get(chunkSize)
+		w.dst = chunkPool.Get(chunkSize)
+	}
+	written := 0
+	for len(p) > 0 {
+		m := copy(w.src[w.n:], p)
+		w.n += m
+		p = p[m:]
+		written += m
+		if err := w.flush(false); err != nil {
+			// The w.n bytes still buffered were not written. Any of them
+			// beyond this call's input are a partial character carried
+			// over from an earlier Write.
+			n := max(written-w.n, 0)
+			w.err, w.n = err, 0
+			return n, err
+		}
+	}
+	return written, nil
+}
+
+// flush encodes the buffered input and writes the result. An incomplete
+// UTF-8 sequence at the end is kept in src unless atEOF is set.
+func (w *Writer) flush(atEOF bool) error {
+	src := w.src[:w.n]
+	for {
+		nDst, nSrc, err := w.t.Transform(w.dst, src, atEOF)
+		if nDst > 0 {
+			if _, werr := w.w.Write(w.dst[:nDst]); werr != nil {
+				// The output of src[:nSrc] did not make it out either.
+				w.n = copy(w.src, src)
+				return werr
+			}
+		}
+		src = src[nSrc:]
+		switch {
+		case err == nil:
+			w.n = 0
+			return nil
+		case errors.Is(err, transform.ErrShortDst) && (nDst > 0 || nSrc > 0):
+			continue
+		case errors.Is(err, transform.ErrShortSrc) && !atEOF:
+			w.n = copy(w.src, src)
+			return nil
+		default:
+			w.n = copy(w.src, src)
+			return err
+		}
+	}
+}
+
+// Close flushes buffered input and returns the buffers to the pool. It
+// does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.src == nil {
+		return w.err
+	}
+	err := w.err
+	if err == nil {
+		err = w.flush(true)
+	}
+	chunkPool.Put(w.src)
+	chunkPool.Put(w.dst)
+	w.src, w.dst = nil, nil
+	return err
+}
@@ -0,0 +1,256 @@
+package bytesconv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+var sampleByEncoding = map[Encoding]string{
+	UTF8:        textTraditional,
+	UTF16LE:     textKorean,
+	UTF16BE:     textJapanese,
+	Big5:        textTraditional,
+	GBK:         textSimplified,
+	ShiftJIS:    textJapanese,
+	EUCKR:       textKorean,
+	Windows1252: textWestern,
+	GB18030:     textSimplified,
+	EUCJP:       textJapanese,
+}
+
+// encodeWith writes s through a Writer one byte at a time, so multi-byte
+// characters are split across Write calls.
+func encodeWith(t *testing.T, enc Encoding, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, enc)
+	if err != nil {
+		t.Fatalf("NewWriter(%s) error = %v", enc, err)
+	}
+	for i := 0; i < len(s); i++ {
+		if _, err := w.Write([]byte{s[i]}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReaderWriterRoundTrip(t *testing.T) {
+	for enc, text := range sampleByEncoding {
+		t.Run(enc.String(), func(t *testing.T) {
+			encoded := encodeWith(t, enc, text)
+			if enc != UTF8 && bytes.Equal(encoded, []byte(text)) {
+				t.Fatal("Writer did not transcode")
+			}
+
+			r, err := NewReader(iotest.OneByteReader(bytes.NewReader(encoded)), enc)
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != text {
+				t.Errorf("round trip = %q, want %q", got, text)
+			}
+		})
+	}
+}
+
+func TestReaderLargeStream(t *testing.T) {
+	// Several chunks worth of data, so characters straddle buffer boundaries.
+	text := strings.Repeat("編號,姓名,地址\n1,王小明,台北市信義區\n", 8000)
+	encoded := encodeWith(t, Big5, text)
+
+	r, _, err := NewDetectReader(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatalf("NewDetectReader() error = %v", err)
+	}
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(got) != text {
+		t.Errorf("decoded %d bytes, want %d", len(got), len(text))
+	}
+}
+
+func TestNewDetectReader(t *testing.T) {
+	big5, err := traditionalchinese.Big5.NewEncoder().String(textTraditional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		input []byte
+		want  Encoding
+		text  string
+	}{
+		{"big5", []byte(big5), Big5, textTraditional},
+		{"utf-8 bom is stripped", append([]byte{0xef, 0xbb, 0xbf}, "a,b\n"...), UTF8, "a,b\n"},
+		{"utf-16le bom is stripped", []byte{0xff, 0xfe, 'o', 0, 'k', 0}, UTF16LE, "ok"},
+		{"empty", nil, UTF8, ""},
+		{"binary falls back to utf-8", []byte{'a', 0, 0xff}, Unknown, "a\x00�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, d, err := NewDetectReader(bytes.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("NewDetectReader() error = %v", err)
+			}
+			defer r.Close()
+			if d.Encoding != tt.want {
+				t.Errorf("Detection = %+v, want %s", d, tt.want)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if string(got) != tt.text {
+				t.Errorf("ReadAll() = %q, want %q", got, tt.text)
+			}
+		})
+	}
+}
+
+func TestNewDetectReaderError(t *testing.T) {
+	boom := errors.New("boom")
+	if _, _, err := NewDetectReader(iotest.ErrReader(boom)); !errors.Is(err, boom) {
+		t.Errorf("NewDetectReader() error = %v, want %v", err, boom)
+	}
+}
+
+func TestReaderPropagatesReadError(t *testing.T) {
+	boom := errors.New("boom")
+	r, err := NewReader(io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(boom)), UTF8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if string(got) != "abc" || !errors.Is(err, boom) {
+		t.Errorf("ReadAll() = %q, %v, want %q, %v", got, err, "abc", boom)
+	}
+}
+
+func TestWriterUnsupportedCharacter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Big5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := w.Write([]byte("ok😀"))
+	if err == nil {
+		t.Fatal("Write() error = nil, want error for unsupported character")
+	}
+	if n != 2 {
+		t.Errorf("Write() n = %d, want 2", n)
+	}
+	_ = w.Close()
+}
+
+// failingWriter accepts ok calls to Write and fails every later one.
+type failingWriter struct {
+	ok  int
+	buf bytes.Buffer
+	err error
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if f.ok == 0 {
+		return 0, f.err
+	}
+	f.ok--
+	return f.buf.Write(p)
+}
+
+func TestWriterStickyError(t *testing.T) {
+	boom := errors.New("boom")
+	fw := &failingWriter{ok: 1, err: boom}
+	w, err := NewWriter(fw, Big5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first Write encodes "a" and keeps the first two bytes of 中.
+	if n, err := w.Write([]byte("a\xe4\xb8")); n != 3 || err != nil {
+		t.Fatalf("Write() = %d, %v", n, err)
+	}
+	p := []byte("\xadb")
+	n, err := w.Write(p)
+	if n != 0 || !errors.Is(err, boom) {
+		t.Fatalf("Write() = %d, %v, want 0, %v", n, err, boom)
+	}
+	// Retrying the unwritten rest must not duplicate output.
+	if n, err := w.Write(p[n:]); n != 0 || !errors.Is(err, boom) {
+		t.Errorf("retried Write() = %d, %v, want 0, %v", n, err, boom)
+	}
+	fw.ok = 1
+	if err := w.Close(); !errors.Is(err, boom) {
+		t.Errorf("Close() error = %v, want %v", err, boom)
+	}
+	if got := fw.buf.String(); got != "a" {
+		t.Errorf("output = %q, want %q", got, "a")
+	}
+}
+
+func TestTranscoderClosed(t *testing.T) {
+	r, err := NewReader(strings.NewReader("abc"), UTF8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read(make([]byte, 4)); err == nil {
+		t.Error("Read() after Close() error = nil")
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+
+	w, err := NewWriter(io.Discard, UTF8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("x")); err == nil {
+		t.Error("Write() after Close() error = nil")
+	}
+}
+
+func TestUnknownEncoding(t *testing.T) {
+	if _, err := NewReader(strings.NewReader(""), Unknown); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("NewReader(Unknown) error = %v", err)
+	}
+	if _, err := NewWriter(io.Discard, Encoding(42)); !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("NewWriter(42) error = %v", err)
+	}
+}
+
+func BenchmarkReaderBig5(b *testing.B) {
+	big5, err := traditionalchinese.Big5.NewEncoder().String(strings.Repeat(textTraditional, 2000))
+	if err != nil {
+		b.Fatal(err)
+	}
+	src := []byte(big5)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		r, _ := NewReader(bytes.NewReader(src), Big5)
+		_, _ = io.Copy(io.Discard, r)
+		r.Close()
+	}
+}
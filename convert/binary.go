@@ -0,0 +1,344 @@
+package convert
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"unsafe"
+
+	"github.com/appleboy/com/bytesconv"
+)
+
+// ErrInvalidLength is returned by FromBytes when the input is not exactly
+// the size of the requested type.
+var ErrInvalidLength = errors.New("convert: byte length does not match the type size")
+
+// byteOrder returns order, or big endian (network byte order) when order
+// is nil.
+func byteOrder(order binary.ByteOrder) binary.ByteOrder {
+	if order == nil {
+		return binary.BigEndian
+	}
+	return order
+}
+
+// ToBytes encodes v in the given byte order. A nil order means big endian.
+//
+// Example:
+//
+//	b := convert.ToBytes(uint16(0x0102), binary.LittleEndian) // []byte{0x02, 0x01}
+func ToBytes[T bytesconv.Number](v T, order binary.ByteOrder) []byte {
+	return AppendBytes(make([]byte, 0, unsafe.Sizeof(v)), v, order)
+}
+
+// AppendBytes appends the encoding of v in the given byte order to b and
+// returns the extended slice. A nil order means big endian.
+func AppendBytes[T bytesconv.Number](b []byte, v T, order binary.ByteOrder) []byte {
+	order = byteOrder(order)
+	switch unsafe.Sizeof(v) {
+	case 1:
+		return append(b, *(*uint8)(unsafe.Pointer(&v)))
+	case 2:
+		return appendUint16(b, order, *(*uint16)(unsafe.Pointer(&v)))
+	case 4:
+		return appendUint32(b, order, *(*uint32)(unsafe.Pointer(&v)))
+	case 8:
+		if isComplex64[T]() {
+			// complex64 is two float32 values, real part first.
+			parts := (*[2]uint32)(unsafe.Pointer(&v))
+			return appendUint32(appendUint32(b, order, parts[0]), order, parts[1])
+		}
+		return appendUint64(b, order, *(*uint64)(unsafe.Pointer(&v)))
+	default: // complex128
+		parts := (*[2]uint64)(unsafe.Pointer(&v))
+		return appendUint64(appendUint64(b, order, parts[0]), order, parts[1])
+	}
+}
+
+// FromBytes decodes a T from b in the given byte order. It returns
+// ErrInvalidLength unless len(b) is exactly the size of T. A nil order
+// means big endian.
+func FromBytes[T bytesconv.Number](b []byte, order binary.ByteOrder) (T, error) {
+	var v T
+	if uintptr(len(b)) != unsafe.Sizeof(v) {
+		return v, fmt.Errorf("%w: got %d bytes, want %d for %T", ErrInvalidLength, len(b), unsafe.Sizeof(v), v)
+	}
+	order = byteOrder(order)
+	switch len(b) {
+	case 1:
+		*(*uint8)(unsafe.Pointer(&v)) = b[0]
+	case 2:
+		*(*uint16)(unsafe.Pointer(&v)) = order.Uint16(b)
+	case 4:
+		*(*uint32)(unsafe.Pointer(&v)) = order.Uint32(b)
+	case 8:
+		if isComplex64[T]() {
+			parts := (*[2]uint32)(unsafe.Pointer(&v))
+			parts[0], parts[1] = order.Uint32(b), order.Uint32(b[4:])
+			break
+		}
+		*(*uint64)(unsafe.Pointer(&v)) = order.Uint64(b)
+	default:
+		parts := (*[2]uint64)(unsafe.Pointer(&v))
+		parts[0], parts[1] = order.Uint64(b), order.Uint64(b[8:])
+	}
+	return v, nil
+}
+
+// isComplex64 reports whether T is complex64 or a type based on it, the
+// only 8-byte Number that is encoded as two halves.
+func isComplex64[T bytesconv.Number]() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Complex64
+}
+
+func appendUint16(b []byte, order binary.ByteOrder, v uint16) []byte {
+	var buf [2]byte
+	order.PutUint16(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint32(b []byte, order binary.ByteOrder, v uint32) []byte {
+	var buf [4]byte
+	order.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, order binary.ByteOrder, v uint64) []byte {
+	var buf [8]byte
+	order.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// ZigZagEncode maps signed integers to unsigned ones so that values close
+// to zero, positive or negative, have small encodings: 0, -1, 1, -2 become
+// 0, 1, 2, 3.
+func ZigZagEncode(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63) // #nosec G115 -- bit manipulation
+}
+
+// ZigZagDecode reverses ZigZagEncode.
+func ZigZagDecode(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1) // #nosec G115 -- bit manipulation
+}
+
+// BinaryWriter appends fixed-size numbers, varints and raw bytes to a
+// growing buffer. Writes never fail.
+type BinaryWriter struct {
+	buf   []byte
+	order binary.ByteOrder
+}
+
+// NewBinaryWriter returns a writer using the given byte order. A nil order
+// means big endian.
+func NewBinaryWriter(order binary.ByteOrder) *BinaryWriter {
+	return &BinaryWriter{order: byteOrder(order)}
+}
+
+// Bytes returns the bytes written so far. The slice aliases the writer's
+// buffer until the next write.
+func (w *BinaryWriter) Bytes() []byte { return w.buf }
+
+// Len returns the number of bytes written.
+func (w *BinaryWriter) Len() int { return len(w.buf) }
+
+// Reset empties the writer, keeping its buffer for reuse.
+func (w *BinaryWriter) Reset() { w.buf = w.buf[:0] }
+
+// Write appends p. It implements io.Writer and always returns len(p), nil.
+func (w *BinaryWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// WriteByte appends c. It implements io.ByteWriter and always returns nil.
+func (w *BinaryWriter) WriteByte(c byte) error {
+	w.buf = append(w.buf, c)
+	return nil
+}
+
+// WriteUint8 appends v.
+func (w *BinaryWriter) WriteUint8(v uint8) { w.buf = append(w.buf, v) }
+
+// WriteUint16 appends v in the writer's byte order.
+func (w *BinaryWriter) WriteUint16(v uint16) { w.buf = appendUint16(w.buf, w.order, v) }
+
+// WriteUint32 appends v in the writer's byte order.
+func (w *BinaryWriter) WriteUint32(v uint32) { w.buf = appendUint32(w.buf, w.order, v) }
+
+// WriteUint64 appends v in the writer's byte order.
+func (w *BinaryWriter) WriteUint64(v uint64) { w.buf = appendUint64(w.buf, w.order, v) }
+
+// WriteInt8 appends v.
+func (w *BinaryWriter) WriteInt8(v int8) { w.WriteUint8(uint8(v)) } // #nosec G115 -- two's complement
+
+// WriteInt16 appends v in the writer's byte order.
+func (w *BinaryWriter) WriteInt16(v int16) { w.WriteUint16(uint16(v)) } // #nosec G115 -- two's complement
+
+// WriteInt32 appends v in the writer's byte order.
+func (w *BinaryWriter) WriteInt32(v int32) { w.WriteUint32(uint32(v)) } // #nosec G115 -- two's complement
+
+// WriteInt64 appends v in the writer's byte order.
+func (w *BinaryWriter) WriteInt64(v int64) { w.WriteUint64(uint64(v)) } // #nosec G115 -- two's complement
+
+// WriteFloat32 appends the IEEE 754 bits of v in the writer's byte order.
+func (w *BinaryWriter) WriteFloat32(v float32) { w.WriteUint32(math.Float32bits(v)) }
+
+// WriteFloat64 appends the IEEE 754 bits of v in the writer's byte order.
+func (w *BinaryWriter) WriteFloat64(v float64) { w.WriteUint64(math.Float64bits(v)) }
+
+// WriteUvarint appends v as an unsigned LEB128 varint, as
+// binary.AppendUvarint does.
+func (w *BinaryWriter) WriteUvarint(v uint64) { w.buf = binary.AppendUvarint(w.buf, v) }
+
+// WriteVarint appends v zigzag encoded as a varint, as binary.AppendVarint
+// does.
+func (w *BinaryWriter) WriteVarint(v int64) { w.buf = binary.AppendVarint(w.buf, v) }
+
+/*
+BinaryReader reads fixed-size numbers, varints and raw bytes from a byte
+slice without panicking. The first failed read sets Err and every later
+read returns zero values, so a message can be parsed in one go and checked
+once at the end.
+
+Example:
+
+	r := convert.NewBinaryReader(packet, binary.BigEndian)
+	version := r.ReadUint8()
+	length := r.ReadUint16()
+	payload := r.ReadBytes(int(length))
+	if err := r.Err(); err != nil {
+		return err
+	}
+*/
+type BinaryReader struct {
+	buf   []byte
+	off   int
+	order binary.ByteOrder
+	err   error
+}
+
+// NewBinaryReader returns a reader over b using the given byte order. A
+// nil order means big endian.
+func NewBinaryReader(b []byte, order binary.ByteOrder) *BinaryReader {
+	return &BinaryReader{buf: b, order: byteOrder(order)}
+}
+
+// Err returns the first error encountered, or nil.
+func (r *BinaryReader) Err() error { return r.err }
+
+// Offset returns the number of bytes consumed.
+func (r *BinaryReader) Offset() int { return r.off }
+
+// Remaining returns the number of unread bytes.
+func (r *BinaryReader) Remaining() int { return len(r.buf) - r.off }
+
+// next consumes n bytes, or records io.ErrUnexpectedEOF and returns nil.
+func (r *BinaryReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > r.Remaining() {
+		r.err = fmt.Errorf("convert: read %d bytes at offset %d with %d remaining: %w",
+			n, r.off, r.Remaining(), io.ErrUnexpectedEOF)
+		return nil
+	}
+	b := r.buf[r.off : r.off+n : r.off+n]
+	r.off += n
+	return b
+}
+
+// Read implements io.Reader over the unread bytes.
+func (r *BinaryReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.Remaining() == 0 && len(p) > 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.buf[r.off:])
+	r.off += n
+	return n, nil
+}
+
+// ReadBytes returns the next n bytes. The result aliases the input.
+func (r *BinaryReader) ReadBytes(n int) []byte { return r.next(n) }
+
+// Skip discards the next n bytes.
+func (r *BinaryReader) Skip(n int) { r.next(n) }
+
+// ReadUint8 reads one byte.
+func (r *BinaryReader) ReadUint8() uint8 {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+// ReadUint16 reads a uint16 in the reader's byte order.
+func (r *BinaryReader) ReadUint16() uint16 {
+	if b := r.next(2); b != nil {
+		return r.order.Uint16(b)
+	}
+	return 0
+}
+
+// ReadUint32 reads a uint32 in the reader's byte order.
+func (r *BinaryReader) ReadUint32() uint32 {
+	if b := r.next(4); b != nil {
+		return r.order.Uint32(b)
+	}
+	return 0
+}
+
+// ReadUint64 reads a uint64 in the reader's byte order.
+func (r *BinaryReader) ReadUint64() uint64 {
+	if b := r.next(8); b != nil {
+		return r.order.Uint64(b)
+	}
+	return 0
+}
+
+// ReadInt8 reads one byte as a signed integer.
+func (r *BinaryReader) ReadInt8() int8 { return int8(r.ReadUint8()) } // #nosec G115 -- two's complement
+
+// ReadInt16 reads an int16 in the reader's byte order.
+func (r *BinaryReader) ReadInt16() int16 { return int16(r.ReadUint16()) } // #nosec G115 -- two's complement
+
+// ReadInt32 reads an int32 in the reader's byte order.
+func (r *BinaryReader) ReadInt32() int32 { return int32(r.ReadUint32()) } // #nosec G115 -- two's complement
+
+// ReadInt64 reads an int64 in the reader's byte order.
+func (r *BinaryReader) ReadInt64() int64 { return int64(r.ReadUint64()) } // #nosec G115 -- two's complement
+
+// ReadFloat32 reads an IEEE 754 float32 in the reader's byte order.
+func (r *BinaryReader) ReadFloat32() float32 { return math.Float32frombits(r.ReadUint32()) }
+
+// ReadFloat64 reads an IEEE 754 float64 in the reader's byte order.
+func (r *BinaryReader) ReadFloat64() float64 { return math.Float64frombits(r.ReadUint64()) }
+
+// ReadUvarint reads an unsigned LEB128 varint. Truncated input records
+// io.ErrUnexpectedEOF and values above 64 bits record ErrOverflow.
+func (r *BinaryReader) ReadUvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf[r.off:])
+	switch {
+	case n == 0:
+		r.err = fmt.Errorf("convert: truncated varint at offset %d: %w", r.off, io.ErrUnexpectedEOF)
+		return 0
+	case n < 0:
+		r.err = fmt.Errorf("convert: varint at offset %d: %w", r.off, ErrOverflow)
+		return 0
+	}
+	r.off += n
+	return v
+}
+
+// ReadVarint reads a zigzag encoded varint.
+func (r *BinaryReader) ReadVarint() int64 {
+	return ZigZagDecode(r.ReadUvarint())
+}
@@ -0,0 +1,189 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+// roundTrip checks ToBytes, AppendBytes and FromBytes for one value
+// against encoding/binary in both byte orders.
+func roundTrip[T interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}](t *testing.T, v T) {
+	t.Helper()
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		var want bytes.Buffer
+		if err := binary.Write(&want, order, v); err != nil {
+			t.Fatal(err)
+		}
+		got := ToBytes(v, order)
+		if !bytes.Equal(got, want.Bytes()) {
+			t.Errorf("ToBytes(%v, %v) = %x, want %x", v, order, got, want.Bytes())
+		}
+		if got := AppendBytes([]byte{0xff}, v, order); !bytes.Equal(got, append([]byte{0xff}, want.Bytes()...)) {
+			t.Errorf("AppendBytes(%v, %v) = %x", v, order, got)
+		}
+		back, err := FromBytes[T](got, order)
+		if err != nil || (back != v && v == v) { // v != v only for NaN
+			t.Errorf("FromBytes(%x, %v) = %v, %v, want %v", got, order, back, err, v)
+		}
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	roundTrip(t, int8(math.MinInt8))
+	roundTrip(t, int16(-2))
+	roundTrip(t, int32(math.MaxInt32))
+	roundTrip(t, int64(math.MinInt64))
+	roundTrip(t, uint8(0xab))
+	roundTrip(t, uint16(0x0102))
+	roundTrip(t, uint32(0xdeadbeef))
+	roundTrip(t, uint64(math.MaxUint64))
+	roundTrip(t, float32(-1.5))
+	roundTrip(t, math.Pi)
+	roundTrip(t, math.Inf(-1))
+	roundTrip(t, complex64(complex(1.5, -2)))
+	roundTrip(t, complex(math.E, math.Pi))
+	roundTrip(t, status32(7))
+}
+
+type status32 int32
+
+func TestToBytesNilOrder(t *testing.T) {
+	if got := ToBytes(uint16(0x0102), nil); !bytes.Equal(got, []byte{1, 2}) {
+		t.Errorf("ToBytes(nil order) = %x, want big endian", got)
+	}
+	if got, err := FromBytes[float64](Float64ToByte(77.99), nil); err != nil || got != 77.99 {
+		t.Errorf("FromBytes(Float64ToByte) = %v, %v", got, err)
+	}
+}
+
+func TestFromBytesInvalidLength(t *testing.T) {
+	for _, b := range [][]byte{nil, {1, 2, 3}, make([]byte, 9)} {
+		if _, err := FromBytes[float64](b, binary.BigEndian); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("FromBytes(%d bytes) error = %v, want ErrInvalidLength", len(b), err)
+		}
+	}
+}
+
+func TestZigZag(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want uint64
+	}{
+		{0, 0}, {-1, 1}, {1, 2}, {-2, 3}, {2147483647, 4294967294},
+		{math.MinInt64, math.MaxUint64}, {math.MaxInt64, math.MaxUint64 - 1},
+	}
+	for _, tt := range tests {
+		if got := ZigZagEncode(tt.in); got != tt.want {
+			t.Errorf("ZigZagEncode(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+		if got := ZigZagDecode(tt.want); got != tt.in {
+			t.Errorf("ZigZagDecode(%d) = %d, want %d", tt.want, got, tt.in)
+		}
+	}
+}
+
+func TestBinaryWriterReader(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		w := NewBinaryWriter(order)
+		w.WriteUint8(1)
+		w.WriteInt8(-2)
+		w.WriteUint16(0x0304)
+		w.WriteInt16(-5)
+		w.WriteUint32(0x06070809)
+		w.WriteInt32(-10)
+		w.WriteUint64(1 << 60)
+		w.WriteInt64(math.MinInt64)
+		w.WriteFloat32(1.25)
+		w.WriteFloat64(-math.Pi)
+		w.WriteUvarint(300)
+		w.WriteVarint(-300)
+		_ = w.WriteByte('x')
+		_, _ = w.Write([]byte("tail"))
+
+		r := NewBinaryReader(w.Bytes(), order)
+		checks := []struct {
+			name      string
+			got, want any
+		}{
+			{"uint8", r.ReadUint8(), uint8(1)},
+			{"int8", r.ReadInt8(), int8(-2)},
+			{"uint16", r.ReadUint16(), uint16(0x0304)},
+			{"int16", r.ReadInt16(), int16(-5)},
+			{"uint32", r.ReadUint32(), uint32(0x06070809)},
+			{"int32", r.ReadInt32(), int32(-10)},
+			{"uint64", r.ReadUint64(), uint64(1 << 60)},
+			{"int64", r.ReadInt64(), int64(math.MinInt64)},
+			{"float32", r.ReadFloat32(), float32(1.25)},
+			{"float64", r.ReadFloat64(), -math.Pi},
+			{"uvarint", r.ReadUvarint(), uint64(300)},
+			{"varint", r.ReadVarint(), int64(-300)},
+			{"byte", r.ReadUint8(), uint8('x')},
+			{"bytes", string(r.ReadBytes(4)), "tail"},
+		}
+		for _, c := range checks {
+			if c.got != c.want {
+				t.Errorf("%v %s = %v, want %v", order, c.name, c.got, c.want)
+			}
+		}
+		if r.Err() != nil || r.Remaining() != 0 || r.Offset() != w.Len() {
+			t.Errorf("%v: Err = %v, Remaining = %d, Offset = %d", order, r.Err(), r.Remaining(), r.Offset())
+		}
+	}
+}
+
+func TestBinaryWriterMatchesEncodingBinary(t *testing.T) {
+	w := NewBinaryWriter(nil)
+	w.WriteUvarint(1 << 40)
+	w.WriteVarint(-1 << 40)
+	want := binary.AppendVarint(binary.AppendUvarint(nil, 1<<40), -1<<40)
+	if !bytes.Equal(w.Bytes(), want) {
+		t.Errorf("varints = %x, want %x", w.Bytes(), want)
+	}
+	w.Reset()
+	if w.Len() != 0 {
+		t.Errorf("Len() after Reset = %d", w.Len())
+	}
+}
+
+func TestBinaryReaderErrors(t *testing.T) {
+	r := NewBinaryReader([]byte{1, 2, 3}, binary.BigEndian)
+	if got := r.ReadUint16(); got != 0x0102 {
+		t.Errorf("ReadUint16() = %x", got)
+	}
+	if got := r.ReadUint32(); got != 0 || !errors.Is(r.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("short ReadUint32() = %v, err = %v", got, r.Err())
+	}
+	// The error is sticky: the remaining byte is not consumed.
+	if got := r.ReadUint8(); got != 0 || r.Offset() != 2 {
+		t.Errorf("ReadUint8() after error = %v at offset %d", got, r.Offset())
+	}
+
+	r = NewBinaryReader([]byte{0x80, 0x80}, nil)
+	if r.ReadUvarint(); !errors.Is(r.Err(), io.ErrUnexpectedEOF) {
+		t.Errorf("truncated varint error = %v", r.Err())
+	}
+	overflow := bytes.Repeat([]byte{0xff}, 10)
+	overflow = append(overflow, 0x01)
+	r = NewBinaryReader(overflow, nil)
+	if r.ReadUvarint(); !errors.Is(r.Err(), ErrOverflow) {
+		t.Errorf("overflowing varint error = %v", r.Err())
+	}
+
+	r = NewBinaryReader([]byte{1}, nil)
+	if b := r.ReadBytes(-1); b != nil || r.Err() == nil {
+		t.Errorf("ReadBytes(-1) = %v, err = %v", b, r.Err())
+	}
+
+	r = NewBinaryReader([]byte("abc"), nil)
+	r.Skip(1)
+	if data, err := io.ReadAll(r); err != nil || string(data) != "bc" {
+		t.Errorf("io.ReadAll() = %q, %v", data, err)
+	}
+}
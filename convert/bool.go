@@ -0,0 +1,137 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrAmbiguousBool is returned by NewBoolProfile when a word is registered
+// as both truthy and falsy.
+var ErrAmbiguousBool = errors.New("convert: word is both truthy and falsy")
+
+// BoolProfile is a set of words that parse as true or false. Matching
+// ignores case and surrounding whitespace.
+type BoolProfile struct {
+	truthy map[string]struct{}
+	falsy  map[string]struct{}
+}
+
+var (
+	// BoolStrict accepts the words strconv.ParseBool accepts: 1, t, true,
+	// 0, f and false.
+	BoolStrict = mustBoolProfile(
+		[]string{"1", "t", "true"},
+		[]string{"0", "f", "false"},
+	)
+	// BoolYAML accepts the YAML 1.1 boolean words: y, yes, true, on and
+	// n, no, false, off.
+	BoolYAML = mustBoolProfile(
+		[]string{"y", "yes", "true", "on"},
+		[]string{"n", "no", "false", "off"},
+	)
+	// BoolEnv accepts the words commonly used in environment variables and
+	// treats an empty value as false.
+	BoolEnv = mustBoolProfile(
+		[]string{"1", "t", "true", "y", "yes", "on", "enable", "enabled"},
+		[]string{"", "0", "f", "false", "n", "no", "off", "disable", "disabled"},
+	)
+)
+
+// NewBoolProfile returns a profile that parses the truthy words as true and
+// the falsy words as false. Words are compared case-insensitively after
+// trimming whitespace. It returns ErrAmbiguousBool if a word is in both sets.
+func NewBoolProfile(truthy, falsy []string) (*BoolProfile, error) {
+	p := &BoolProfile{
+		truthy: make(map[string]struct{}, len(truthy)),
+		falsy:  make(map[string]struct{}, len(falsy)),
+	}
+	for _, w := range truthy {
+		p.truthy[normalizeBoolWord(w)] = struct{}{}
+	}
+	for _, w := range falsy {
+		w = normalizeBoolWord(w)
+		if _, ok := p.truthy[w]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrAmbiguousBool, w)
+		}
+		p.falsy[w] = struct{}{}
+	}
+	return p, nil
+}
+
+func mustBoolProfile(truthy, falsy []string) *BoolProfile {
+	p, err := NewBoolProfile(truthy, falsy)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func normalizeBoolWord(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Parse reports the boolean value of s. Words outside the profile return a
+// *ConversionError wrapping ErrSyntax.
+func (p *BoolProfile) Parse(s string) (bool, error) {
+	w := normalizeBoolWord(s)
+	if _, ok := p.truthy[w]; ok {
+		return true, nil
+	}
+	if _, ok := p.falsy[w]; ok {
+		return false, nil
+	}
+	return false, &ConversionError{Value: s, Target: reflect.TypeFor[bool](), Err: ErrSyntax}
+}
+
+/*
+Converter holds the conversion semantics for one call site. The zero value
+is ready to use and parses booleans with BoolStrict.
+
+Example:
+
+	env := convert.Converter{Bool: convert.BoolEnv}
+	debug := env.ToBool(os.Getenv("DEBUG")) // "", "0", "off" and "no" are false
+*/
+type Converter struct {
+	// Bool is the profile used to parse booleans. Nil means BoolStrict.
+	Bool *BoolProfile
+}
+
+func (c Converter) boolProfile() *BoolProfile {
+	if c.Bool == nil {
+		return BoolStrict
+	}
+	return c.Bool
+}
+
+// ParseBool converts value to a bool. Strings are matched against the
+// converter's profile, and numbers are matched by their decimal form, so
+// 1 and 0 are accepted only by profiles that list them. Errors are
+// *ConversionError values.
+func (c Converter) ParseBool(value any) (bool, error) {
+	s, ok := toScalar(value)
+	if !ok {
+		return false, &ConversionError{Value: value, Target: reflect.TypeFor[bool](), Err: ErrUnsupportedType}
+	}
+	if s.kind == kindBool {
+		return s.b, nil
+	}
+	str, err := toStringStrict(value)
+	if err != nil {
+		return false, &ConversionError{Value: value, Target: reflect.TypeFor[bool](), Err: err}
+	}
+	v, err := c.boolProfile().Parse(str)
+	if err != nil {
+		return false, &ConversionError{Value: value, Target: reflect.TypeFor[bool](), Err: ErrSyntax}
+	}
+	return v, nil
+}
+
+// ToBool is like ParseBool but returns false for values the profile does
+// not recognize.
+func (c Converter) ToBool(value any) bool {
+	v, _ := c.ParseBool(value)
+	return v
+}
@@ -0,0 +1,117 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBoolProfiles(t *testing.T) {
+	type result struct {
+		want bool
+		ok   bool
+	}
+	tests := []struct {
+		input  string
+		strict result
+		yaml   result
+		env    result
+	}{
+		{"true", result{true, true}, result{true, true}, result{true, true}},
+		{" TRUE\n", result{true, true}, result{true, true}, result{true, true}},
+		{"False", result{false, true}, result{false, true}, result{false, true}},
+		{"1", result{true, true}, result{false, false}, result{true, true}},
+		{"0", result{false, true}, result{false, false}, result{false, true}},
+		{"t", result{true, true}, result{false, false}, result{true, true}},
+		{"yes", result{false, false}, result{true, true}, result{true, true}},
+		{"No", result{false, false}, result{false, true}, result{false, true}},
+		{"ON", result{false, false}, result{true, true}, result{true, true}},
+		{"off", result{false, false}, result{false, true}, result{false, true}},
+		{"y", result{false, false}, result{true, true}, result{true, true}},
+		{"n", result{false, false}, result{false, true}, result{false, true}},
+		{"enabled", result{false, false}, result{false, false}, result{true, true}},
+		{"Disable", result{false, false}, result{false, false}, result{false, true}},
+		{"", result{false, false}, result{false, false}, result{false, true}},
+		{"maybe", result{false, false}, result{false, false}, result{false, false}},
+	}
+	profiles := []struct {
+		name    string
+		profile *BoolProfile
+		result  func(i int) result
+	}{
+		{"strict", BoolStrict, func(i int) result { return tests[i].strict }},
+		{"yaml", BoolYAML, func(i int) result { return tests[i].yaml }},
+		{"env", BoolEnv, func(i int) result { return tests[i].env }},
+	}
+	for _, p := range profiles {
+		for i, tt := range tests {
+			want := p.result(i)
+			got, err := p.profile.Parse(tt.input)
+			if (err == nil) != want.ok || got != want.want {
+				t.Errorf("%s.Parse(%q) = %v, %v, want %v, ok=%v", p.name, tt.input, got, err, want.want, want.ok)
+			}
+			if err != nil && !errors.Is(err, ErrSyntax) {
+				t.Errorf("%s.Parse(%q) error = %v, want ErrSyntax", p.name, tt.input, err)
+			}
+		}
+	}
+}
+
+func TestNewBoolProfile(t *testing.T) {
+	p, err := NewBoolProfile([]string{"Ja", "oui"}, []string{"nein", " NON "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for input, want := range map[string]bool{"ja": true, "OUI": true, "Nein": false, "non": false} {
+		if got, err := p.Parse(input); err != nil || got != want {
+			t.Errorf("Parse(%q) = %v, %v, want %v", input, got, err, want)
+		}
+	}
+	if _, err := p.Parse("yes"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Parse(yes) error = %v, want ErrSyntax", err)
+	}
+	if _, err := NewBoolProfile([]string{"x"}, []string{"X"}); !errors.Is(err, ErrAmbiguousBool) {
+		t.Errorf("NewBoolProfile() error = %v, want ErrAmbiguousBool", err)
+	}
+}
+
+func TestConverterBool(t *testing.T) {
+	var strict Converter
+	env := Converter{Bool: BoolEnv}
+	yaml := Converter{Bool: BoolYAML}
+
+	tests := []struct {
+		name  string
+		conv  Converter
+		value any
+		want  bool
+		err   error
+	}{
+		{"zero value is strict", strict, "yes", false, ErrSyntax},
+		{"strict number", strict, 1, true, nil},
+		{"strict number out of set", strict, 2, false, ErrSyntax},
+		{"env off", env, "off", false, nil},
+		{"env pointer", env, ToPtr("Enabled"), true, nil},
+		{"env empty", env, "", false, nil},
+		{"yaml number", yaml, 1, false, ErrSyntax},
+		{"yaml bool", yaml, true, true, nil},
+		{"named string", yaml, userID("on"), true, nil},
+		{"unsupported", env, struct{}{}, false, ErrUnsupportedType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.conv.ParseBool(tt.value)
+			if got != tt.want || !errors.Is(err, tt.err) {
+				t.Errorf("ParseBool(%v) = %v, %v, want %v, %v", tt.value, got, err, tt.want, tt.err)
+			}
+			if err != nil {
+				var cerr *ConversionError
+				if !errors.As(err, &cerr) || cerr.Value != tt.value {
+					t.Errorf("ParseBool(%v) error = %#v, want *ConversionError for the input", tt.value, err)
+				}
+			}
+			if got := tt.conv.ToBool(tt.value); got != tt.want {
+				t.Errorf("ToBool(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package convert
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commonInitialisms is the list of initialisms golint keeps upper case in
+// Go identifiers.
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML",
+	"HTTP", "HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC",
+	"SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID",
+	"UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// Caser converts between naming conventions. Every method splits its input
+// into words the same way, so any output can be converted to any other
+// convention without losing word boundaries:
+//   - non-letter, non-digit characters such as '_', '-', '.' and spaces
+//     separate words and are dropped
+//   - an upper case letter after a lower case letter or a digit starts a
+//     word ("fooBar" is foo, Bar)
+//   - the last letter of an upper case run starts a word when a lower case
+//     letter follows ("HTTPServer" is HTTP, Server), except for a plural
+//     's' ("IDs" stays one word)
+//   - digits belong to the word before them ("Base64Encode" is Base64,
+//     Encode)
+//   - letters without case, such as Chinese, form their own words
+//
+// Upper and lower case are decided by the unicode package.
+type Caser struct {
+	initialisms map[string]struct{}
+}
+
+// maxInitialism is the longest initialism a Caser recognizes.
+const maxInitialism = 16
+
+// NewCaser returns a Caser that writes the given initialisms in upper case
+// in camel, Pascal and title case output. Pass CommonInitialisms(), possibly
+// extended, for golint's behavior. Initialisms are ASCII and at most 16
+// bytes long; longer ones are ignored.
+func NewCaser(initialisms ...string) *Caser {
+	c := &Caser{initialisms: make(map[string]struct{}, len(initialisms))}
+	for _, s := range initialisms {
+		if len(s) <= maxInitialism {
+			c.initialisms[strings.ToUpper(s)] = struct{}{}
+		}
+	}
+	return c
+}
+
+// CommonInitialisms returns a copy of the initialisms golint keeps upper
+// case in Go identifiers, which the package level case functions use.
+// Changing the copy does not affect them; pass it to NewCaser instead.
+func CommonInitialisms() []string {
+	return slices.Clone(commonInitialisms)
+}
+
+var defaultCaser = NewCaser(commonInitialisms...)
+
+// runeClass is the role of a rune in eachWord.
+type runeClass int
+
+const (
+	classSeparator runeClass = iota
+	classUpper
+	classLower
+	classDigit
+	classCaseless
+)
+
+func classify(r rune) runeClass {
+	if r < utf8.RuneSelf {
+		switch {
+		case 'a' <= r && r <= 'z':
+			return classLower
+		case 'A' <= r && r <= 'Z':
+			return classUpper
+		case '0' <= r && r <= '9':
+			return classDigit
+		}
+		return classSeparator
+	}
+	switch {
+	case unicode.IsUpper(r):
+		return classUpper
+	case unicode.IsLower(r):
+		return classLower
+	case unicode.IsDigit(r):
+		return classDigit
+	case unicode.IsLetter(r):
+		return classCaseless
+	}
+	return classSeparator
+}
+
+// eachWord calls fn with every word of s, split with the rules documented
+// on Caser. Words are substrings of s.
+func eachWord(s string, fn func(word string)) {
+	start := -1
+	prev := classSeparator
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cur := classify(r)
+		switch {
+		case cur == classSeparator:
+			if start >= 0 {
+				fn(s[start:i])
+				start = -1
+			}
+		case start < 0:
+			start = i
+		case wordBoundary(prev, cur, s[i+size:]):
+			fn(s[start:i])
+			start = i
+		}
+		prev = cur
+		i += size
+	}
+	if start >= 0 {
+		fn(s[start:])
+	}
+}
+
+// wordBoundary reports whether a rune of class cur starts a new word after
+// a rune of class prev in the same word. rest is the input after it.
+func wordBoundary(prev, cur runeClass, rest string) bool {
+	switch {
+	case cur == classCaseless || prev == classCaseless:
+		return cur != prev && cur != classDigit
+	case cur == classUpper:
+		if prev != classUpper {
+			return prev == classLower || prev == classDigit
+		}
+		next, size := utf8.DecodeRuneInString(rest)
+		if classify(next) != classLower {
+			return false
+		}
+		// Keep plural initialisms such as "IDs" or "URLs" together.
+		if next == 's' {
+			after, _ := utf8.DecodeRuneInString(rest[size:])
+			return len(rest) > size && classify(after) == classLower
+		}
+		return true
+	}
+	return false
+}
+
+// splitWords returns the words of s.
+func splitWords(s string) []string {
+	var words []string
+	eachWord(s, func(w string) { words = append(words, w) })
+	return words
+}
+
+// isInitialism reports whether word, compared case-insensitively, is one
+// of c's initialisms.
+func (c *Caser) isInitialism(word string) bool {
+	if len(word) > maxInitialism {
+		return false
+	}
+	// Upper case into a stack buffer; the map lookup does not copy it.
+	var buf [maxInitialism]byte
+	for i := 0; i < len(word); i++ {
+		b := word[i]
+		if b >= utf8.RuneSelf {
+			return false
+		}
+		if 'a' <= b && b <= 'z' {
+			b -= 'a' - 'A'
+		}
+		buf[i] = b
+	}
+	_, ok := c.initialisms[string(buf[:len(word)])]
+	return ok
+}
+
+func writeUpper(b *strings.Builder, s string) {
+	for _, r := range s {
+		b.WriteRune(unicode.ToUpper(r))
+	}
+}
+
+func writeLower(b *strings.Builder, s string) {
+	for _, r := range s {
+		b.WriteRune(unicode.ToLower(r))
+	}
+}
+
+// writeTitle writes word with its first letter upper case and the rest
+// lower case, or all upper case if it is a known initialism. Plural
+// initialisms keep a lower case 's': "IDs", "URLs".
+func (c *Caser) writeTitle(b *strings.Builder, word string) {
+	if c.isInitialism(word) {
+		writeUpper(b, word)
+		return
+	}
+	if n := len(word); n > 1 && (word[n-1] == 's' || word[n-1] == 'S') && c.isInitialism(word[:n-1]) {
+		writeUpper(b, word[:n-1])
+		b.WriteByte('s')
+		return
+	}
+	r, size := utf8.DecodeRuneInString(word)
+	b.WriteRune(unicode.ToUpper(r))
+	writeLower(b, word[size:])
+}
+
+func (c *Caser) join(s, sep string, write func(b *strings.Builder, i int, w string)) string {
+	var b strings.Builder
+	b.Grow(len(s) + len(s)/2)
+	i := 0
+	eachWord(s, func(w string) {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		write(&b, i, w)
+		i++
+	})
+	return b.String()
+}
+
+func lowerWord(b *strings.Builder, _ int, w string) { writeLower(b, w) }
+
+func upperWord(b *strings.Builder, _ int, w string) { writeUpper(b, w) }
+
+// ToSnake converts s to snake_case: "HTTPServer" becomes "http_server".
+func (c *Caser) ToSnake(s string) string { return c.join(s, "_", lowerWord) }
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE: "maxConn" becomes
+// "MAX_CONN".
+func (c *Caser) ToScreamingSnake(s string) string { return c.join(s, "_", upperWord) }
+
+// ToKebab converts s to kebab-case: "HTTPServer" becomes "http-server".
+func (c *Caser) ToKebab(s string) string { return c.join(s, "-", lowerWord) }
+
+// ToDot converts s to dot.case: "HTTPServer" becomes "http.server".
+func (c *Caser) ToDot(s string) string { return c.join(s, ".", lowerWord) }
+
+// ToCamel converts s to camelCase: "user_id" becomes "userID" and
+// "ID_token" becomes "idToken".
+func (c *Caser) ToCamel(s string) string {
+	return c.join(s, "", func(b *strings.Builder, i int, w string) {
+		if i == 0 {
+			writeLower(b, w)
+			return
+		}
+		c.writeTitle(b, w)
+	})
+}
+
+// ToPascal converts s to PascalCase: "http_server_id" becomes
+// "HTTPServerID".
+func (c *Caser) ToPascal(s string) string {
+	return c.join(s, "", func(b *strings.Builder, _ int, w string) { c.writeTitle(b, w) })
+}
+
+// ToTitleWords converts s to space separated title case words:
+// "apiKeyID" becomes "API Key ID".
+func (c *Caser) ToTitleWords(s string) string {
+	return c.join(s, " ", func(b *strings.Builder, _ int, w string) { c.writeTitle(b, w) })
+}
+
+// ToSnake converts s to snake_case using CommonInitialisms(). Unlike
+// SnakeCasedName it keeps acronyms together: "HTTPServer" becomes
+// "http_server".
+func ToSnake(s string) string { return defaultCaser.ToSnake(s) }
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE.
+func ToScreamingSnake(s string) string { return defaultCaser.ToScreamingSnake(s) }
+
+// ToKebab converts s to kebab-case.
+func ToKebab(s string) string { return defaultCaser.ToKebab(s) }
+
+// ToDot converts s to dot.case.
+func ToDot(s string) string { return defaultCaser.ToDot(s) }
+
+// ToCamel converts s to camelCase, keeping CommonInitialisms() upper case.
+func ToCamel(s string) string { return defaultCaser.ToCamel(s) }
+
+// ToPascal converts s to PascalCase, keeping CommonInitialisms() upper case.
+func ToPascal(s string) string { return defaultCaser.ToPascal(s) }
+
+// ToTitleWords converts s to space separated title case words, keeping
+// CommonInitialisms() upper case.
+func ToTitleWords(s string) string { return defaultCaser.ToTitleWords(s) }
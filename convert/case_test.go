@@ -0,0 +1,124 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"foo", []string{"foo"}},
+		{"fooBar", []string{"foo", "Bar"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"userID", []string{"user", "ID"}},
+		{"UserIDs", []string{"User", "IDs"}},
+		{"parseURLsFast", []string{"parse", "URLs", "Fast"}},
+		{"Base64Encode", []string{"Base64", "Encode"}},
+		{"utf8String", []string{"utf8", "String"}},
+		{"ID3Tag", []string{"ID3", "Tag"}},
+		{"v2beta", []string{"v2beta"}},
+		{"foo_bar-baz.qux quux", []string{"foo", "bar", "baz", "qux", "quux"}},
+		{"__leading__and__trailing__", []string{"leading", "and", "trailing"}},
+		{"你好World", []string{"你好", "World"}},
+		{"World你好", []string{"World", "你好"}},
+		{"ÉcoleNormale", []string{"École", "Normale"}},
+		{"straßeÜber", []string{"straße", "Über"}},
+		{"ΑβγΔεζ", []string{"Αβγ", "Δεζ"}},
+		{"ABC", []string{"ABC"}},
+	}
+	for _, tt := range tests {
+		if got := splitWords(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitWords(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		in        string
+		snake     string
+		screaming string
+		kebab     string
+		dot       string
+		camel     string
+		pascal    string
+		title     string
+	}{
+		{"HTTPServer", "http_server", "HTTP_SERVER", "http-server", "http.server", "httpServer", "HTTPServer", "HTTP Server"},
+		{"user_id", "user_id", "USER_ID", "user-id", "user.id", "userID", "UserID", "User ID"},
+		{"api-key-ids", "api_key_ids", "API_KEY_IDS", "api-key-ids", "api.key.ids", "apiKeyIDs", "APIKeyIDs", "API Key IDs"},
+		{"MAX_CONN", "max_conn", "MAX_CONN", "max-conn", "max.conn", "maxConn", "MaxConn", "Max Conn"},
+		{"utf8 string", "utf8_string", "UTF8_STRING", "utf8-string", "utf8.string", "utf8String", "UTF8String", "UTF8 String"},
+		{"你好World", "你好_world", "你好_WORLD", "你好-world", "你好.world", "你好World", "你好World", "你好 World"},
+		{"école normale", "école_normale", "ÉCOLE_NORMALE", "école-normale", "école.normale", "écoleNormale", "ÉcoleNormale", "École Normale"},
+		{"", "", "", "", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			check := func(name, got, want string) {
+				if got != want {
+					t.Errorf("%s(%q) = %q, want %q", name, tt.in, got, want)
+				}
+			}
+			check("ToSnake", ToSnake(tt.in), tt.snake)
+			check("ToScreamingSnake", ToScreamingSnake(tt.in), tt.screaming)
+			check("ToKebab", ToKebab(tt.in), tt.kebab)
+			check("ToDot", ToDot(tt.in), tt.dot)
+			check("ToCamel", ToCamel(tt.in), tt.camel)
+			check("ToPascal", ToPascal(tt.in), tt.pascal)
+			check("ToTitleWords", ToTitleWords(tt.in), tt.title)
+		})
+	}
+}
+
+func TestCaseRoundTrip(t *testing.T) {
+	conversions := map[string]func(string) string{
+		"ToSnake":          ToSnake,
+		"ToScreamingSnake": ToScreamingSnake,
+		"ToKebab":          ToKebab,
+		"ToDot":            ToDot,
+		"ToCamel":          ToCamel,
+		"ToPascal":         ToPascal,
+		"ToTitleWords":     ToTitleWords,
+	}
+	idents := []string{
+		"HTTPServer", "ServeHTTP", "UserID", "UserIDs", "APIKey", "JSONToXML",
+		"NewUUIDString", "Base64Encode", "ParseURL", "TLSConfig", "SimpleName",
+	}
+	for _, id := range idents {
+		for name, conv := range conversions {
+			if got := ToPascal(conv(id)); got != id {
+				t.Errorf("ToPascal(%s(%q)) = %q, want %q", name, id, got, id)
+			}
+		}
+	}
+}
+
+func TestCustomInitialisms(t *testing.T) {
+	c := NewCaser(append(CommonInitialisms(), "k8s", "GRPC")...)
+	if got := c.ToPascal("grpc_k8s_client"); got != "GRPCK8SClient" {
+		t.Errorf("ToPascal() = %q", got)
+	}
+	if got := c.ToCamel("grpc_k8s_client"); got != "grpcK8SClient" {
+		t.Errorf("ToCamel() = %q", got)
+	}
+
+	none := NewCaser()
+	if got := none.ToPascal("http_server_id"); got != "HttpServerId" {
+		t.Errorf("ToPascal() without initialisms = %q", got)
+	}
+	if got := ToPascal("grpc_client"); got != "GrpcClient" {
+		t.Errorf("default ToPascal() = %q, custom initialisms leaked", got)
+	}
+}
+
+func BenchmarkToSnake(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		stringOut = ToSnake("HTTPServerMaxConnIDs")
+	}
+}
@@ -0,0 +1,167 @@
+package convert
+
+import (
+	"embed"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// The conversion tables in dict use the OpenCC text format: one
+// "key<TAB>value [value...]" entry per line, where the first value is the
+// one used. They are a hand-curated subset of the OpenCC tables covering the
+// common characters plus the phrases needed to choose between one-to-many
+// character mappings, such as 头发 (頭髮) against 发展 (發展), rather than the
+// complete OpenCC data. They are derived from OpenCC
+// (https://github.com/BYVoid/OpenCC) under the Apache License 2.0; see
+// dict/NOTICE for the attribution and the changes made.
+//
+//go:embed dict/*.txt
+var chineseDicts embed.FS
+
+var (
+	dictS2T = sync.OnceValue(func() *chineseDict { return loadChineseDict("STCharacters", "STPhrases") })
+	dictT2S = sync.OnceValue(func() *chineseDict { return loadChineseDict("TSCharacters", "TSPhrases") })
+	dictTW  = sync.OnceValue(func() *chineseDict { return loadChineseDict("TWVariants", "TWPhrases") })
+	dictHK  = sync.OnceValue(func() *chineseDict { return loadChineseDict("HKVariants") })
+)
+
+// chineseDict is a set of OpenCC tables merged into one map and matched
+// longest key first.
+type chineseDict struct {
+	m map[string]string
+	// maxLen is the byte length of the longest key starting with each rune,
+	// indexed by rune, so text without any key is skipped quickly.
+	maxLen []uint8
+}
+
+// loadChineseDict merges the named tables, later tables taking precedence.
+// The tables are embedded, so a malformed one is a programming error.
+func loadChineseDict(names ...string) *chineseDict {
+	d := &chineseDict{m: make(map[string]string)}
+	for _, name := range names {
+		data, err := chineseDicts.ReadFile("dict/" + name + ".txt")
+		if err != nil {
+			panic("convert: " + err.Error())
+		}
+		for line := range strings.Lines(string(data)) {
+			key, values, ok := strings.Cut(strings.TrimRight(line, "\r\n"), "\t")
+			value, _, _ := strings.Cut(values, " ")
+			if !ok || key == "" || value == "" || len(key) > 255 {
+				panic("convert: malformed line in " + name + ": " + line)
+			}
+			d.m[key] = value
+		}
+	}
+	for key := range d.m {
+		r, _ := utf8.DecodeRuneInString(key)
+		if int(r) >= len(d.maxLen) {
+			d.maxLen = append(d.maxLen, make([]uint8, int(r)+1-len(d.maxLen))...)
+		}
+		d.maxLen[r] = max(d.maxLen[r], uint8(len(key)))
+	}
+	return d
+}
+
+// convert replaces every longest match in s, scanning left to right. It
+// returns s itself when nothing matches.
+func (d *chineseDict) convert(s string) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if int(r) >= len(d.maxLen) || d.maxLen[r] == 0 {
+			i += size
+			continue
+		}
+		n, value := d.match(s[i:], int(d.maxLen[r]), size)
+		if n == 0 {
+			i += size
+			continue
+		}
+		if b.Cap() == 0 {
+			b.Grow(len(s) + len(s)/8)
+		}
+		b.WriteString(s[last:i])
+		b.WriteString(value)
+		i += n
+		last = i
+	}
+	if b.Cap() == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// match returns the length and replacement of the longest key that
+// prefixes s, trying at most maxLen bytes and at least the first rune of
+// firstSize bytes.
+func (d *chineseDict) match(s string, maxLen, firstSize int) (int, string) {
+	end := min(maxLen, len(s))
+	for end < len(s) && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	for end >= firstSize {
+		if value, ok := d.m[s[:end]]; ok {
+			return end, value
+		}
+		_, size := utf8.DecodeLastRuneInString(s[:end])
+		end -= size
+	}
+	return 0, ""
+}
+
+/*
+ToTraditional converts Simplified Chinese to Traditional Chinese. Phrases
+are matched before single characters, longest first, so characters with
+several Traditional forms are resolved from context. Other text, including
+Traditional characters, passes through unchanged.
+
+Example:
+
+	convert.ToTraditional("头发的发展") // "頭髮的發展"
+*/
+func ToTraditional(s string) string {
+	return dictS2T().convert(s)
+}
+
+/*
+ToSimplified converts Traditional Chinese to Simplified Chinese. It accepts
+the Taiwan and Hong Kong character variants produced by ToTaiwan and
+ToHongKong, but it does not translate Taiwan vocabulary back: 軟體 becomes
+软体, not 软件.
+
+Example:
+
+	convert.ToSimplified("頭髮的發展") // "头发的发展"
+*/
+func ToSimplified(s string) string {
+	return dictT2S().convert(s)
+}
+
+/*
+ToTaiwan converts Simplified Chinese to Traditional Chinese as written in
+Taiwan, using Taiwan character variants and common Taiwan terms. Only a
+small set of variants and terms is covered, not the full OpenCC tables.
+
+Example:
+
+	convert.ToTaiwan("软件里的内存") // "軟體裡的記憶體"
+*/
+func ToTaiwan(s string) string {
+	return dictTW().convert(dictS2T().convert(s))
+}
+
+/*
+ToHongKong converts Simplified Chinese to Traditional Chinese using the
+Hong Kong character variants. Only a small set of variants is covered, not
+the full OpenCC tables.
+
+Example:
+
+	convert.ToHongKong("说明") // "説明"
+*/
+func ToHongKong(s string) string {
+	return dictHK().convert(dictS2T().convert(s))
+}
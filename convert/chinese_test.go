@@ -0,0 +1,162 @@
+package convert
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestToTraditional(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"hello, 世界", "hello, 世界"},
+		{"简体中文", "簡體中文"},
+		{"头发的发展", "頭髮的發展"},
+		{"干净的饼干，干部若干", "乾淨的餅乾，幹部若干"},
+		{"皇后以后吃面条", "皇后以後吃麵條"},
+		{"这只是一只猫", "這只是一隻貓"},
+		{"复杂的答复", "複雜的答覆"},
+		{"关系与联系", "關係與聯繫"},
+		{"台风登陆台湾", "颱風登陸臺灣"},
+		{"这里有一公里", "這裏有一公里"},
+		{"我听着音乐", "我聽著音樂"},
+		{"已经是繁體字", "已經是繁體字"},
+	}
+	for _, tt := range tests {
+		if got := ToTraditional(tt.in); got != tt.want {
+			t.Errorf("ToTraditional(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToSimplified(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"hello, 世界", "hello, 世界"},
+		{"簡體中文", "简体中文"},
+		{"頭髮的發展", "头发的发展"},
+		{"乾淨的餅乾", "干净的饼干"},
+		{"乾隆皇帝", "乾隆皇帝"},
+		{"著名作家正看著書", "著名作家正看着书"},
+		{"複雜的答覆", "复杂的答复"},
+		// Taiwan and Hong Kong variants.
+		{"這裡", "这里"},
+		{"説明", "说明"},
+		// Taiwan vocabulary is not translated back.
+		{"軟體", "软体"},
+	}
+	for _, tt := range tests {
+		if got := ToSimplified(tt.in); got != tt.want {
+			t.Errorf("ToSimplified(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToTaiwan(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"软件里的内存", "軟體裡的記憶體"},
+		{"网络信息与数据库", "網路資訊與資料庫"},
+		{"程序员写代码", "程式設計師寫程式碼"},
+		{"打印机打印字符串", "印表機列印字串"},
+		{"不要吸烟", "不要吸菸"},
+	}
+	for _, tt := range tests {
+		if got := ToTaiwan(tt.in); got != tt.want {
+			t.Errorf("ToTaiwan(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToHongKong(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"说明", "説明"},
+		{"这里的黄色窗户", "這裏的黄色窗户"},
+		{"软件", "軟件"},
+	}
+	for _, tt := range tests {
+		if got := ToHongKong(tt.in); got != tt.want {
+			t.Errorf("ToHongKong(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestChineseRoundTrip(t *testing.T) {
+	if got := ToSimplified(ToTraditional(chineseDoc)); got != chineseDoc {
+		t.Errorf("ToSimplified(ToTraditional(doc)) changed the text:\n%s", got)
+	}
+}
+
+func TestChineseUnchangedDoesNotAllocate(t *testing.T) {
+	s := strings.Repeat("plain ASCII text, 繁體字 ", 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		if ToTraditional(s) != s {
+			t.Fatal("text changed")
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("ToTraditional allocated %v times for unchanged text", allocs)
+	}
+}
+
+func TestChineseDictsWellFormed(t *testing.T) {
+	files, err := fs.Glob(chineseDicts, "dict/*.txt")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no dictionaries embedded: %v", err)
+	}
+	for _, name := range files {
+		data, _ := chineseDicts.ReadFile(name)
+		seen := make(map[string]bool)
+		for line := range strings.Lines(string(data)) {
+			key, values, ok := strings.Cut(strings.TrimSuffix(line, "\n"), "\t")
+			if !ok || key == "" || values == "" || strings.Contains(values, "  ") {
+				t.Errorf("%s: malformed line %q", name, line)
+			}
+			if seen[key] {
+				t.Errorf("%s: duplicate key %q", name, key)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+// chineseDoc is a short Simplified Chinese article; the benchmarks repeat it
+// into long documents.
+const chineseDoc = `随着互联网的发展，越来越多的人通过网络获取信息。开发者在编写软件时，` +
+	`经常需要处理简体中文与繁体中文之间的转换。这个问题看起来简单，其实相当复杂：` +
+	`同一个简体字可能对应多个繁体字，例如头发的发和发展的发，干净的干和干部的干。` +
+	`因此转换程序必须先匹配词组，再处理单个汉字。我们在这里测试一篇较长的文章，` +
+	`其中包括数字123、English words，以及各种标点符号。`
+
+func benchmarkChinese(b *testing.B, fn func(string) string, doc string) {
+	doc = strings.Repeat(doc, 500)
+	fn(doc) // load the dictionaries outside the timed loop
+	b.SetBytes(int64(len(doc)))
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = fn(doc)
+	}
+}
+
+func BenchmarkToTraditional(b *testing.B) {
+	benchmarkChinese(b, ToTraditional, chineseDoc)
+}
+
+func BenchmarkToSimplified(b *testing.B) {
+	benchmarkChinese(b, ToSimplified, ToTraditional(chineseDoc))
+}
+
+func BenchmarkToTaiwan(b *testing.B) {
+	benchmarkChinese(b, ToTaiwan, chineseDoc)
+}
+
+func BenchmarkToHongKong(b *testing.B) {
+	benchmarkChinese(b, ToHongKong, chineseDoc)
+}
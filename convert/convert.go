@@ -1,26 +1,40 @@
 package convert
 
 import (
-	"fmt"
-	"io"
 	"math"
 	"strconv"
-	"strings"
+	"unicode/utf8"
 
 	"github.com/appleboy/com/bytesconv"
 	"golang.org/x/text/encoding/traditionalchinese"
 	"golang.org/x/text/transform"
 )
 
-// ToString convert any type to string
+// ToString convert any type to string.
+// driver.Valuer, fmt.Stringer and encoding.TextMarshaler values are
+// formatted with those methods, and pointers are formatted as the value
+// they point to; a nil pointer formats as "<nil>".
 func ToString(value any) string {
-	if v, ok := value.(*string); ok {
-		return *v
+	switch v := value.(type) {
+	case string:
+		return v
+	case *string:
+		if v != nil {
+			return *v
+		}
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
 	}
-	return fmt.Sprintf("%v", value)
+	return formatValue(value)
 }
 
-// ToBool convert any type to boolean
+// ToBool convert any type to boolean.
+// Every string other than "" and "false" is true; use a Converter with a
+// BoolProfile to parse words such as "0", "no" or "off".
 func ToBool(value any) bool {
 	switch value := value.(type) {
 	case bool:
@@ -57,6 +71,21 @@ func ToBool(value any) bool {
 	case *int:
 		return ToBool(*value)
 	}
+	if s, ok := reflectScalar(value); ok {
+		switch s.kind {
+		case kindBool:
+			return s.b
+		case kindString:
+			return ToBool(s.s)
+		case kindInt:
+			return s.i != 0
+		case kindUint:
+			return s.u != 0
+		case kindFloat:
+			return s.f != 0
+		case kindInvalid:
+		}
+	}
 	return false
 }
 
@@ -133,6 +162,21 @@ func ToInt(value any) any {
 	case *string:
 		return ToInt(*value)
 	}
+	if s, ok := reflectScalar(value); ok {
+		switch s.kind {
+		case kindBool:
+			return ToInt(s.b)
+		case kindString:
+			return ToInt(s.s)
+		case kindInt:
+			return ToInt(s.i)
+		case kindUint:
+			return ToInt(s.u)
+		case kindFloat:
+			return ToInt(s.f)
+		case kindInvalid:
+		}
+	}
 
 	// If the value cannot be transformed into an int, return nil instead of '0'
 	// to denote 'no integer found'
@@ -195,9 +239,14 @@ func float64ToInt(value float64) any {
 	return int(value)
 }
 
-// stringToInt parses string and converts to int
+// stringToInt parses string and converts to int.
+// Integer syntax is parsed exactly; other numeric syntax such as "1.5" or
+// "1e3" falls back to a float64 parse.
 func stringToInt(value string) any {
-	val, err := strconv.ParseFloat(value, 32)
+	if i, err := bytesconv.ParseInt(bytesconv.StrToBytes(value), 10, 64); err == nil {
+		return ToInt(i)
+	}
+	val, err := strconv.ParseFloat(value, 64)
 	if err != nil {
 		return nil
 	}
@@ -275,6 +324,30 @@ func ToFloat(value any) any {
 		return val
 	case *string:
 		return ToFloat(*value)
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64,
+		*int, *int8, *int16, *int64, *uint, *uint8, *uint16, *uint32, *uint64:
+		if val, err := toFloatStrict(value, 64); err == nil {
+			return val
+		}
+		// Nil pointers fall through to 0.0 as before.
+	}
+	if s, ok := reflectScalar(value); ok {
+		switch s.kind {
+		case kindString:
+			return ToFloat(s.s)
+		case kindFloat:
+			if s.bits == 32 {
+				return float32(s.f)
+			}
+			return s.f
+		case kindBool:
+			return float64(boolToInt(s.b))
+		case kindInt:
+			return float64(s.i)
+		case kindUint:
+			return float64(s.u)
+		case kindInvalid:
+		}
 	}
 	return 0.0
 }
@@ -305,10 +378,14 @@ func FromPtr[T any](ptr *T) T {
 	return *ptr
 }
 
+// bufPool holds scratch buffers for the encoding conversions.
+var bufPool bytesconv.Pool
+
 // ConvertBig5ToUTF8 converts a string encoded in Big5 to a UTF-8 encoded string.
 // The input parameter s must be a string encoded in Big5, and the function returns the corresponding UTF-8 string.
-// This function uses the Go standard library's transform package for conversion, which results in a string allocation.
-// If the conversion fails (e.g., if the input is not valid Big5), the original string is returned and no panic occurs.
+// The conversion runs in a pooled scratch buffer, so the only allocation is the returned string.
+// Invalid Big5 sequences are replaced with U+FFFD rather than reported, and no panic occurs.
+// Use Transcode to get an error instead.
 //
 // Usage Example:
 //
@@ -316,13 +393,23 @@ func FromPtr[T any](ptr *T) T {
 //	utf8Str := ConvertBig5ToUTF8(big5Str)
 //	fmt.Println(utf8Str) // Output: 中文
 func ConvertBig5ToUTF8(s string) string {
-	reader := transform.NewReader(
-		strings.NewReader(s),
-		traditionalchinese.Big5.NewDecoder(),
-	)
-	d, err := io.ReadAll(reader)
-	if err != nil {
-		return s
+	src := bytesconv.StrToBytes(s)
+	dec := traditionalchinese.Big5.NewDecoder()
+	// Big5 characters decode from two bytes to three, so 3/2 of the input
+	// is enough for well-formed text; invalid bytes grow the buffer below.
+	dst := bufPool.Get(len(s) + len(s)/2 + utf8.UTFMax)
+	for {
+		nDst, _, err := dec.Transform(dst[:cap(dst)], src, true)
+		if err == nil {
+			out := string(dst[:nDst])
+			bufPool.Put(dst)
+			return out
+		}
+		bufPool.Put(dst)
+		if err != transform.ErrShortDst {
+			return s
+		}
+		dst = bufPool.Get(2 * cap(dst))
+		dec.Reset()
 	}
-	return bytesconv.BytesToStr(d)
 }
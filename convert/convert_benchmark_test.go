@@ -0,0 +1,46 @@
+package convert
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/appleboy/com/bytesconv"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/transform"
+)
+
+// convertBig5ToUTF8Reader is the previous reader based implementation of
+// ConvertBig5ToUTF8, kept for comparison.
+func convertBig5ToUTF8Reader(s string) string {
+	reader := transform.NewReader(
+		strings.NewReader(s),
+		traditionalchinese.Big5.NewDecoder(),
+	)
+	d, err := io.ReadAll(reader)
+	if err != nil {
+		return s
+	}
+	return bytesconv.BytesToStr(d)
+}
+
+// big5Text is "你好，世界" in Big5 repeated to the size of a typical record.
+var big5Text = strings.Repeat("\xa7A\xa6n\xa1A\xa5@\xac\xc9", 64)
+
+var big5Sink string
+
+func BenchmarkConvertBig5ToUTF8Old(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(big5Text)))
+	for i := 0; i < b.N; i++ {
+		big5Sink = convertBig5ToUTF8Reader(big5Text)
+	}
+}
+
+func BenchmarkConvertBig5ToUTF8New(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(big5Text)))
+	for i := 0; i < b.N; i++ {
+		big5Sink = ConvertBig5ToUTF8(big5Text)
+	}
+}
@@ -2,6 +2,7 @@ package convert
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -108,6 +109,48 @@ func TestToInt(t *testing.T) {
 			},
 			want: 0,
 		},
+		{
+			name: "string beyond float32 precision",
+			args: args{
+				value: "16777217",
+			},
+			want: 16777217,
+		},
+		{
+			name: "string int32 max",
+			args: args{
+				value: "2147483647",
+			},
+			want: 2147483647,
+		},
+		{
+			name: "string out of int32 range",
+			args: args{
+				value: "2147483648",
+			},
+			want: nil,
+		},
+		{
+			name: "string float",
+			args: args{
+				value: "12.9",
+			},
+			want: 12,
+		},
+		{
+			name: "string exponent",
+			args: args{
+				value: "1e3",
+			},
+			want: 1000,
+		},
+		{
+			name: "string invalid",
+			args: args{
+				value: "abc",
+			},
+			want: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -182,6 +225,11 @@ func TestConvertBig5ToUTF8(t *testing.T) {
 			input: "Hello, World!",
 			want:  "Hello, World!",
 		},
+		{
+			name:  "Output larger than initial buffer",
+			input: strings.Repeat("\xff", 100),
+			want:  strings.Repeat("\ufffd", 100),
+		},
 	}
 
 	for _, tt := range tests {
@@ -286,6 +334,20 @@ func TestSliceToPtrSlice(t *testing.T) {
 	})
 }
 
+func TestSliceToPtrSliceCopy(t *testing.T) {
+	src := []int{1, 2, 3}
+	ptrs := SliceToPtrSliceCopy(src)
+	src[0] = 100
+	for i, want := range []int{1, 2, 3} {
+		if ptrs[i] == nil || *ptrs[i] != want {
+			t.Errorf("ptrs[%d] = %v, want %d", i, ptrs[i], want)
+		}
+	}
+	if got := SliceToPtrSliceCopy([]string{}); len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
+
 func TestPtrSliceToSlice(t *testing.T) {
 	t.Run("int64", func(t *testing.T) {
 		a, b := int64(10), int64(20)
@@ -326,6 +388,21 @@ func TestMapToPtrMap(t *testing.T) {
 			}
 		}
 	})
+	t.Run("int key", func(t *testing.T) {
+		src := map[int]string{1: "one", 2: "two"}
+		ptrMap := MapToPtrMap(src)
+		for k, v := range src {
+			if ptrMap[k] == nil || *ptrMap[k] != v {
+				t.Errorf("ptrMap[%d] = %v, want %q", k, ptrMap[k], v)
+			}
+		}
+	})
+	t.Run("explicit value type", func(t *testing.T) {
+		ptrMap := MapToPtrMap[int](map[string]int{"a": 1})
+		if ptrMap["a"] == nil || *ptrMap["a"] != 1 {
+			t.Errorf("ptrMap[a] = %v, want 1", ptrMap["a"])
+		}
+	})
 }
 
 func TestPtrMapToMap(t *testing.T) {
@@ -347,4 +424,59 @@ func TestPtrMapToMap(t *testing.T) {
 			t.Errorf("got %v, want %v", valMap, want)
 		}
 	})
+	t.Run("struct key", func(t *testing.T) {
+		type key struct{ x, y int }
+		v := "origin"
+		src := map[key]*string{{0, 0}: &v, {1, 1}: nil}
+		valMap := PtrMapToMap(src)
+		want := map[key]string{{0, 0}: "origin"}
+		if !reflect.DeepEqual(valMap, want) {
+			t.Errorf("got %v, want %v", valMap, want)
+		}
+	})
+}
+
+func TestCoalescePtr(t *testing.T) {
+	a, b := 1, 2
+	if got := CoalescePtr(nil, &a, &b); got != &a {
+		t.Errorf("CoalescePtr() = %v, want &a", got)
+	}
+	if got := CoalescePtr[int](nil, nil); got != nil {
+		t.Errorf("CoalescePtr() = %v, want nil", got)
+	}
+	if got := CoalescePtr[int](); got != nil {
+		t.Errorf("CoalescePtr() = %v, want nil", got)
+	}
+}
+
+func TestPtrEqual(t *testing.T) {
+	a, b, c := "x", "x", "y"
+	tests := []struct {
+		name string
+		a, b *string
+		want bool
+	}{
+		{"both nil", nil, nil, true},
+		{"left nil", nil, &a, false},
+		{"right nil", &a, nil, false},
+		{"same pointer", &a, &a, true},
+		{"equal values", &a, &b, true},
+		{"different values", &a, &c, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PtrEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("PtrEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDerefOr(t *testing.T) {
+	if got := DerefOr(ToPtr(0), 8080); got != 0 {
+		t.Errorf("DerefOr() = %d, want 0", got)
+	}
+	if got := DerefOr(nil, 8080); got != 8080 {
+		t.Errorf("DerefOr() = %d, want 8080", got)
+	}
 }
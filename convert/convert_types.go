@@ -1,8 +1,10 @@
 package convert
 
-// SliceToPtrSlice converts a slice of values ([]T) to a slice of pointers ([]*T).
-// Each element in the result points to the corresponding element in the input slice.
-// Note: The pointers are valid only as long as the input slice is not modified.
+// SliceToPtrSlice returns pointers into src: the result aliases the input slice,
+// so each element points to the corresponding element of src and sees any later
+// change to it.
+//
+// Deprecated: Use SliceToPtrSliceCopy, which does not alias the input.
 func SliceToPtrSlice[T any](src []T) []*T {
 	dst := make([]*T, len(src))
 	for i := range src {
@@ -11,6 +13,18 @@ func SliceToPtrSlice[T any](src []T) []*T {
 	return dst
 }
 
+// SliceToPtrSliceCopy converts a slice of values ([]T) to a slice of pointers ([]*T).
+// Unlike SliceToPtrSlice, each element points to its own copy, so the result stays
+// valid when the input slice is modified.
+func SliceToPtrSliceCopy[T any](src []T) []*T {
+	dst := make([]*T, len(src))
+	for i, v := range src {
+		val := v
+		dst[i] = &val
+	}
+	return dst
+}
+
 // PtrSliceToSlice converts a slice of pointers ([]*T) to a slice of values ([]T).
 // If an element in the input slice is nil, the zero value of T is used.
 func PtrSliceToSlice[T any](src []*T) []T {
@@ -23,10 +37,11 @@ func PtrSliceToSlice[T any](src []*T) []T {
 	return dst
 }
 
-// MapToPtrMap converts a map of values (map[string]T) to a map of pointers (map[string]*T).
+// MapToPtrMap converts a map of values (map[K]V) to a map of pointers (map[K]*V).
 // Each value in the result points to a copy of the corresponding value in the input map.
-func MapToPtrMap[T any](src map[string]T) map[string]*T {
-	dst := make(map[string]*T, len(src))
+// The value type comes first so MapToPtrMap[T] still instantiates for map[string]T.
+func MapToPtrMap[V any, K comparable](src map[K]V) map[K]*V {
+	dst := make(map[K]*V, len(src))
 	for k, v := range src {
 		val := v
 		dst[k] = &val
@@ -34,10 +49,11 @@ func MapToPtrMap[T any](src map[string]T) map[string]*T {
 	return dst
 }
 
-// PtrMapToMap converts a map of pointers (map[string]*T) to a map of values (map[string]T).
+// PtrMapToMap converts a map of pointers (map[K]*V) to a map of values (map[K]V).
 // If a value in the input map is nil, the key is omitted in the result.
-func PtrMapToMap[T any](src map[string]*T) map[string]T {
-	dst := make(map[string]T, len(src))
+// The value type comes first so PtrMapToMap[T] still instantiates for map[string]*T.
+func PtrMapToMap[V any, K comparable](src map[K]*V) map[K]V {
+	dst := make(map[K]V, len(src))
 	for k, v := range src {
 		if v != nil {
 			dst[k] = *v
@@ -45,3 +61,29 @@ func PtrMapToMap[T any](src map[string]*T) map[string]T {
 	}
 	return dst
 }
+
+// CoalescePtr returns the first non-nil pointer in ptrs, or nil if all of them are nil.
+func CoalescePtr[T any](ptrs ...*T) *T {
+	for _, p := range ptrs {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// PtrEqual reports whether a and b are both nil, or both non-nil and point to equal values.
+func PtrEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// DerefOr returns the value p points to, or def if p is nil.
+func DerefOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
@@ -0,0 +1,507 @@
+package convert
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/appleboy/com/bytesconv"
+)
+
+// CSVOptions configures CSVReader and CSVWriter. A nil *CSVOptions uses the
+// defaults.
+type CSVOptions struct {
+	// Encoding is the character encoding of the file. The zero value,
+	// bytesconv.Unknown, detects the encoding when reading, which handles
+	// byte order marks, Big5 and the other legacy encodings Detect knows,
+	// and writes UTF-8.
+	Encoding bytesconv.Encoding
+	// Comma is the field delimiter. The default is ','; use '\t' for TSV.
+	Comma rune
+	// LazyQuotes accepts quotes in unquoted fields and unescaped quotes in
+	// quoted fields, as some spreadsheet exports produce.
+	LazyQuotes bool
+	// UseCRLF ends written lines with \r\n instead of \n.
+	UseCRLF bool
+	// TagName is the struct tag holding the column name for each field.
+	// The default is "csv".
+	TagName string
+	// WeaklyTyped retries failed conversions with the lenient converters,
+	// as in DecodeOptions.
+	WeaklyTyped bool
+	// Bools, if set, parses boolean cells instead of the strict To rules,
+	// for files that write booleans as "Y"/"N" or "是"/"否".
+	Bools *BoolProfile
+}
+
+func (o *CSVOptions) orDefault() *CSVOptions {
+	out := CSVOptions{}
+	if o != nil {
+		out = *o
+	}
+	if out.Comma == 0 {
+		out.Comma = ','
+	}
+	if out.TagName == "" {
+		out.TagName = "csv"
+	}
+	return &out
+}
+
+// CSVError records why a cell, or a whole row when Column is 0, could not
+// be read or written. Row is the 1-based line number in the file, so the
+// header is row 1; Column is 1-based and Header is the column's name.
+type CSVError struct {
+	Row    int
+	Column int
+	Header string
+	Err    error
+}
+
+func (e *CSVError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("convert: csv row %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("convert: csv row %d, column %d (%s): %v", e.Row, e.Column, e.Header, e.Err)
+}
+
+func (e *CSVError) Unwrap() error { return e.Err }
+
+// CSVErrors lists every CSVError found, in file order.
+type CSVErrors []*CSVError
+
+func (e CSVErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ce := range e {
+		msgs[i] = ce.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap lets errors.Is and errors.As inspect every CSV error.
+func (e CSVErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, ce := range e {
+		errs[i] = ce
+	}
+	return errs
+}
+
+/*
+CSVReader reads CSV or TSV records into structs, transcoding the input to
+UTF-8 as it streams. The first row is the header. Each column is matched to
+a field as Decode matches map keys: by tag name (TagName, "csv" by default),
+Go name, SnakeCasedName of the Go name or TitleCasedName of the header, so
+a "user_name" column fills UserName without a tag. Cells are converted with
+the To rules, or the options' lenient rules; empty cells leave the field
+unchanged and columns without a field are ignored.
+
+Call Close when done to return the transcoding buffers to the pool; it does
+not close the underlying reader.
+
+Example:
+
+	r, _ := convert.NewCSVReader(f, &convert.CSVOptions{Encoding: bytesconv.Big5})
+	defer r.Close()
+	for {
+		var rec Order
+		err := r.Read(&rec)
+		if err == io.EOF {
+			break
+		}
+		var bad convert.CSVErrors
+		if errors.As(err, &bad) {
+			log.Print(bad) // skip the row, keep importing
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		orders = append(orders, rec)
+	}
+*/
+type CSVReader struct {
+	r       *csv.Reader
+	src     *bytesconv.Reader
+	header  []string
+	columns map[string]int
+	d       decoder
+}
+
+// NewCSVReader returns a CSVReader for r and reads the header row. An empty
+// input fails with io.EOF.
+func NewCSVReader(r io.Reader, opts *CSVOptions) (*CSVReader, error) {
+	o := opts.orDefault()
+	var src *bytesconv.Reader
+	var err error
+	if o.Encoding == bytesconv.Unknown {
+		src, _, err = bytesconv.NewDetectReader(r)
+	} else {
+		src, err = bytesconv.NewReader(r, o.Encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(src)
+	cr.Comma = o.Comma
+	cr.LazyQuotes = o.LazyQuotes
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	c := &CSVReader{
+		r:       cr,
+		src:     src,
+		header:  make([]string, len(header)),
+		columns: make(map[string]int, len(header)),
+		d:       decoder{tag: o.TagName, weak: o.WeaklyTyped, bools: o.Bools},
+	}
+	for i, h := range header {
+		if i == 0 {
+			h = strings.TrimPrefix(h, "\ufeff")
+		}
+		h = strings.TrimSpace(h)
+		c.header[i] = h
+		if _, dup := c.columns[h]; !dup {
+			c.columns[h] = i
+		}
+	}
+	return c, nil
+}
+
+// Header returns the column names from the first row, with surrounding
+// spaces and any byte order mark removed.
+func (c *CSVReader) Header() []string { return c.header }
+
+// Read decodes the next record into the struct dst points to. It returns
+// io.EOF after the last record. Problems confined to the record, such as a
+// cell that does not convert or a row with the wrong number of fields, are
+// returned as CSVErrors and reading can continue; dst then holds the cells
+// that did convert. Any other error is final.
+func (c *CSVReader) Read(dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	record, err := c.r.Read()
+	if err != nil {
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			return CSVErrors{{Row: pe.StartLine, Err: pe.Err}}
+		}
+		return err
+	}
+	row, _ := c.r.FieldPos(0)
+
+	src := make(map[string]any, len(record))
+	for i, cell := range record {
+		if i < len(c.header) && cell != "" && c.columns[c.header[i]] == i {
+			src[c.header[i]] = cell
+		}
+	}
+	c.d.errs = nil
+	c.d.decodeStruct("", src, rv.Elem())
+	if len(c.d.errs) == 0 {
+		return nil
+	}
+	errs := make(CSVErrors, len(c.d.errs))
+	for i, fe := range c.d.errs {
+		errs[i] = &CSVError{Row: row, Column: c.columns[fe.key] + 1, Header: fe.key, Err: fe.Err}
+	}
+	return errs
+}
+
+// Close returns the transcoding buffers to the pool. It does not close the
+// underlying reader.
+func (c *CSVReader) Close() error { return c.src.Close() }
+
+/*
+ReadCSV reads every record of r into a []T, where T is a struct type, as
+CSVReader does. Records with errors are left out and the import goes on;
+the returned error is then a CSVErrors listing every failing row and cell.
+Errors that stop the import, such as a failing reader, are returned as they
+are, along with the records read so far.
+
+Example:
+
+	orders, err := convert.ReadCSV[Order](f, &convert.CSVOptions{Encoding: bytesconv.Big5})
+*/
+func ReadCSV[T any](r io.Reader, opts *CSVOptions) ([]T, error) {
+	if reflect.TypeFor[T]().Kind() != reflect.Struct {
+		return nil, ErrInvalidTarget
+	}
+	cr, err := NewCSVReader(r, opts)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cr.Close()
+
+	var out []T
+	var errs CSVErrors
+	for {
+		var rec T
+		err := cr.Read(&rec)
+		if err == io.EOF {
+			break
+		}
+		var rowErrs CSVErrors
+		if errors.As(err, &rowErrs) {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		if err != nil {
+			return out, err
+		}
+		out = append(out, rec)
+	}
+	if len(errs) > 0 {
+		return out, errs
+	}
+	return out, nil
+}
+
+// csvColumn is a struct field written as a CSV column.
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// csvColumns lists the fields of t in order, flattening embedded structs
+// without a tag like Encode. Columns are named by tag, or by SnakeCasedName
+// of the Go name.
+func csvColumns(t reflect.Type, tag string, index []int) []csvColumn {
+	var cols []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, tagged, skip := fieldName(f, tag)
+		if skip {
+			continue
+		}
+		idx := append(index[:len(index):len(index)], i)
+		if f.Anonymous && !tagged {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				cols = append(cols, csvColumns(ft, tag, idx)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if !tagged {
+			name = SnakeCasedName(f.Name)
+		}
+		cols = append(cols, csvColumn{name: name, index: idx})
+	}
+	return cols
+}
+
+/*
+CSVWriter writes structs as CSV or TSV records in the chosen encoding. The
+header row is written before the first record, from the fields of its type:
+the tag name (TagName, "csv" by default) or SnakeCasedName of the Go name,
+so files read back with CSVReader into the same type. Embedded structs
+without a tag are flattened and fields tagged "-" are skipped.
+
+Cells are formatted with encoding.TextMarshaler when the field implements
+it, time.Duration.String for durations and the To rules otherwise; nil
+pointers are written as empty cells. A cell that cannot be formatted, or a
+character the encoding cannot represent, fails Write with a *CSVError
+naming the row and column, and nothing of that record is written.
+
+Output is buffered: call Flush when done.
+
+Example:
+
+	w, _ := convert.NewCSVWriter(f, &convert.CSVOptions{Encoding: bytesconv.Big5, UseCRLF: true})
+	for _, o := range orders {
+		if err := w.Write(o); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+*/
+type CSVWriter struct {
+	out  *bufio.Writer
+	line bytes.Buffer
+	csv  *csv.Writer
+	// t is nil when writing UTF-8.
+	t    *Transcoder
+	tag  string
+	typ  reflect.Type
+	cols []csvColumn
+	row  int
+	// cells is reused for each record.
+	cells []string
+}
+
+// NewCSVWriter returns a CSVWriter writing to w.
+func NewCSVWriter(w io.Writer, opts *CSVOptions) (*CSVWriter, error) {
+	o := opts.orDefault()
+	c := &CSVWriter{out: bufio.NewWriter(w), tag: o.TagName}
+	if o.Encoding != bytesconv.Unknown && o.Encoding != bytesconv.UTF8 {
+		if _, err := o.Encoding.TextEncoding(); err != nil {
+			return nil, err
+		}
+		c.t = &Transcoder{From: bytesconv.UTF8, To: o.Encoding}
+	}
+	c.csv = csv.NewWriter(&c.line)
+	c.csv.Comma = o.Comma
+	c.csv.UseCRLF = o.UseCRLF
+	return c, nil
+}
+
+// Write writes src, a struct or a pointer to one, as the next record. Every
+// record must have the same type as the first.
+func (c *CSVWriter) Write(src any) error {
+	rv, ok := indirect(reflect.ValueOf(src))
+	if !ok || rv.Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	if c.typ == nil {
+		if err := c.writeHeader(rv.Type()); err != nil {
+			return err
+		}
+	} else if rv.Type() != c.typ {
+		return fmt.Errorf("%w: record of type %v after %v", ErrUnsupportedType, rv.Type(), c.typ)
+	}
+
+	for i, col := range c.cols {
+		fv, err := rv.FieldByIndexErr(col.index)
+		if err != nil {
+			// A nil embedded pointer: its fields are empty.
+			c.cells[i] = ""
+			continue
+		}
+		if c.cells[i], err = csvCell(fv); err != nil {
+			return &CSVError{Row: c.row + 1, Column: i + 1, Header: col.name, Err: err}
+		}
+	}
+	return c.writeLine()
+}
+
+// writeHeader fixes the record type to t and writes its column names.
+func (c *CSVWriter) writeHeader(t reflect.Type) error {
+	c.typ = t
+	c.cols = csvColumns(t, c.tag, nil)
+	c.cells = make([]string, len(c.cols))
+	for i, col := range c.cols {
+		c.cells[i] = col.name
+	}
+	return c.writeLine()
+}
+
+// writeLine encodes c.cells as the next line.
+func (c *CSVWriter) writeLine() error {
+	c.line.Reset()
+	if err := c.csv.Write(c.cells); err != nil {
+		return err
+	}
+	c.csv.Flush()
+	if err := c.csv.Error(); err != nil {
+		return err
+	}
+	line := c.line.Bytes()
+	if c.t != nil {
+		encoded, err := c.t.Bytes(line)
+		if err != nil {
+			return c.cellError(err)
+		}
+		line = encoded
+	}
+	c.row++
+	_, err := c.out.Write(line)
+	return err
+}
+
+// cellError finds the cell holding the character that could not be
+// encoded.
+func (c *CSVWriter) cellError(err error) error {
+	for i, cell := range c.cells {
+		if _, cellErr := c.t.String(cell); cellErr != nil {
+			return &CSVError{Row: c.row + 1, Column: i + 1, Header: c.cols[i].name, Err: cellErr}
+		}
+	}
+	return &CSVError{Row: c.row + 1, Err: err}
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (c *CSVWriter) Flush() error { return c.out.Flush() }
+
+// csvCell formats a field value as a cell.
+func csvCell(v reflect.Value) (string, error) {
+	v, ok := indirect(v)
+	if !ok {
+		return "", nil
+	}
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String(), nil
+	}
+	if m, ok := textMarshaler(v); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return string(v.Bytes()), nil
+	}
+	return toStringStrict(v.Interface())
+}
+
+func textMarshaler(v reflect.Value) (encoding.TextMarshaler, bool) {
+	if v.Type().Implements(textMarshalerType) {
+		return v.Interface().(encoding.TextMarshaler), true
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		return v.Addr().Interface().(encoding.TextMarshaler), true
+	}
+	return nil, false
+}
+
+/*
+WriteCSV writes records as CSV with a header row, as CSVWriter does, and
+flushes the output. The header is written even when records is empty.
+
+Example:
+
+	err := convert.WriteCSV(f, orders, &convert.CSVOptions{Encoding: bytesconv.Big5})
+*/
+func WriteCSV[T any](w io.Writer, records []T, opts *CSVOptions) error {
+	cw, err := NewCSVWriter(w, opts)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		t := reflect.TypeFor[T]()
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return ErrInvalidTarget
+		}
+		if err := cw.writeHeader(t); err != nil {
+			return err
+		}
+	}
+	for _, rec := range records {
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
@@ -0,0 +1,247 @@
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/appleboy/com/bytesconv"
+)
+
+type csvAudit struct {
+	Created time.Time `csv:"created"`
+}
+
+type csvOrder struct {
+	ID        int64 `csv:"訂單編號"`
+	Customer  string
+	UnitPrice float64
+	Paid      bool
+	Timeout   time.Duration
+	Note      *string
+	Secret    string `csv:"-"`
+	csvAudit
+}
+
+func mustBig5(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := ConvertUTF8ToBig5(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []byte(b)
+}
+
+func TestReadCSVBig5(t *testing.T) {
+	in := mustBig5(t, "訂單編號,customer,unit_price,paid,timeout,note,created,extra\n"+
+		"1001,王小明,12.5,true,30s,急件,2024-05-20T08:00:00Z,x\n"+
+		"abc,陳大文,3,maybe,,,,\n"+
+		"1003,\"林,美麗\",,false,1m,,2024-05-21T00:00:00Z,\n"+
+		"1004,short\n")
+
+	got, err := ReadCSV[csvOrder](bytes.NewReader(in), &CSVOptions{Encoding: bytesconv.Big5})
+	var errs CSVErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("ReadCSV error = %v, want 3 CSVErrors", err)
+	}
+	wantErrs := []struct {
+		row, col int
+		header   string
+		err      error
+	}{
+		{3, 1, "訂單編號", ErrSyntax},
+		{3, 4, "paid", ErrSyntax},
+		{5, 0, "", nil},
+	}
+	for i, w := range wantErrs {
+		e := errs[i]
+		if e.Row != w.row || e.Column != w.col || e.Header != w.header || (w.err != nil && !errors.Is(e, w.err)) {
+			t.Errorf("errs[%d] = %+v, want row %d column %d %q %v", i, e, w.row, w.col, w.header, w.err)
+		}
+	}
+	if !strings.Contains(errs[0].Error(), "row 3, column 1 (訂單編號)") {
+		t.Errorf("error message = %q", errs[0].Error())
+	}
+
+	note := "急件"
+	want := []csvOrder{
+		{ID: 1001, Customer: "王小明", UnitPrice: 12.5, Paid: true, Timeout: 30 * time.Second, Note: &note,
+			csvAudit: csvAudit{Created: time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC)}},
+		{ID: 1003, Customer: "林,美麗", Timeout: time.Minute,
+			csvAudit: csvAudit{Created: time.Date(2024, 5, 21, 0, 0, 0, 0, time.UTC)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCSV = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestCSVReaderOptions(t *testing.T) {
+	// A UTF-8 byte order mark is detected and removed from the header.
+	in := "\ufeffName\tActive\n Ada \t是\nBob\t否\n"
+	yesNo, err := NewBoolProfile([]string{"是"}, []string{"否"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewCSVReader(strings.NewReader(in), &CSVOptions{Comma: '\t', Bools: yesNo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	if h := r.Header(); !reflect.DeepEqual(h, []string{"Name", "Active"}) {
+		t.Errorf("Header() = %q", h)
+	}
+	type person struct {
+		Name   string
+		Active bool
+	}
+	var got []person
+	for {
+		var p person
+		err := r.Read(&p)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, p)
+	}
+	want := []person{{" Ada ", true}, {"Bob", false}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %+v, want %+v", got, want)
+	}
+
+	if err := r.Read(person{}); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("Read(non-pointer) error = %v, want ErrInvalidTarget", err)
+	}
+
+	type count struct{ N int }
+	recs, err := ReadCSV[count](strings.NewReader("n\n12.9\n"), &CSVOptions{WeaklyTyped: true})
+	if err != nil || len(recs) != 1 || recs[0].N != 12 {
+		t.Errorf("WeaklyTyped ReadCSV = %+v, %v", recs, err)
+	}
+	if recs, err := ReadCSV[count](strings.NewReader(""), nil); recs != nil || err != nil {
+		t.Errorf("ReadCSV(empty) = %v, %v", recs, err)
+	}
+	type total struct {
+		Amount int64 `csv:"amount"`
+	}
+	totals, err := ReadCSV[total](strings.NewReader("amount\n3000000000.5\n"), &CSVOptions{WeaklyTyped: true})
+	if err != nil || len(totals) != 1 || totals[0].Amount != 3000000000 {
+		t.Errorf("WeaklyTyped int64 ReadCSV = %+v, %v", totals, err)
+	}
+	if _, err := ReadCSV[int](strings.NewReader("n\n1\n"), nil); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("ReadCSV[int] error = %v, want ErrInvalidTarget", err)
+	}
+}
+
+func TestReadCSVDottedHeader(t *testing.T) {
+	type item struct {
+		Name  string  `csv:"name"`
+		Price float64 `csv:"unit.price"`
+	}
+	_, err := ReadCSV[item](strings.NewReader("name,unit.price\npen,cheap\n"), nil)
+	var errs CSVErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("ReadCSV error = %v, want 1 CSVError", err)
+	}
+	if e := errs[0]; e.Row != 2 || e.Column != 2 || e.Header != "unit.price" || !errors.Is(e, ErrSyntax) {
+		t.Errorf("error = %+v, want row 2 column 2 (unit.price)", e)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	note := "急件"
+	orders := []csvOrder{
+		{ID: 1001, Customer: "王小明", UnitPrice: 12.5, Paid: true, Timeout: 90 * time.Second, Note: &note,
+			Secret: "hidden", csvAudit: csvAudit{Created: time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC)}},
+		{ID: 1002, Customer: "林,美麗", csvAudit: csvAudit{Created: time.Date(2024, 5, 21, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	var utf8 bytes.Buffer
+	if err := WriteCSV(&utf8, orders, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := "訂單編號,customer,unit_price,paid,timeout,note,created\n" +
+		"1001,王小明,12.5,true,1m30s,急件,2024-05-20T08:00:00Z\n" +
+		"1002,\"林,美麗\",0,false,0s,,2024-05-21T00:00:00Z\n"
+	if utf8.String() != want {
+		t.Errorf("WriteCSV =\n%s\nwant\n%s", utf8.String(), want)
+	}
+
+	var big5 bytes.Buffer
+	opts := &CSVOptions{Encoding: bytesconv.Big5, UseCRLF: true}
+	if err := WriteCSV(&big5, orders, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := big5.String(); got != string(mustBig5(t, strings.ReplaceAll(want, "\n", "\r\n"))) {
+		t.Errorf("Big5 output = %q", got)
+	}
+	back, err := ReadCSV[csvOrder](&big5, opts)
+	orders[0].Secret = ""
+	if err != nil || !reflect.DeepEqual(back, orders) {
+		t.Errorf("round trip = %+v, %v\nwant %+v", back, err, orders)
+	}
+
+	var empty bytes.Buffer
+	if err := WriteCSV(&empty, []*csvAudit(nil), nil); err != nil || empty.String() != "created\n" {
+		t.Errorf("WriteCSV(nil) = %q, %v", empty.String(), err)
+	}
+}
+
+func TestCSVWriterErrors(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewCSVWriter(&out, &CSVOptions{Encoding: bytesconv.Big5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(&csvOrder{ID: 1, Customer: "王"}); err != nil {
+		t.Fatal(err)
+	}
+	err = w.Write(csvOrder{ID: 2, Customer: "笑😀"})
+	var ce *CSVError
+	if !errors.As(err, &ce) || ce.Row != 3 || ce.Column != 2 || ce.Header != "customer" || !errors.Is(err, ErrUnrepresentable) {
+		t.Errorf("Write(emoji) error = %v, want row 3 column 2 ErrUnrepresentable", err)
+	}
+	if err := w.Write(csvAudit{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Write(other type) error = %v, want ErrUnsupportedType", err)
+	}
+	if err := w.Write(3); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("Write(3) error = %v, want ErrInvalidTarget", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// The failed record left no partial line behind.
+	if lines := strings.Count(out.String(), "\n"); lines != 2 {
+		t.Errorf("wrote %d lines, want 2:\n%q", lines, out.String())
+	}
+
+	type nested struct {
+		Tags []string
+	}
+	err = WriteCSV(io.Discard, []nested{{Tags: []string{"a"}}}, nil)
+	if !errors.As(err, &ce) || ce.Header != "tags" || !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("WriteCSV(slice field) error = %v, want ErrUnsupportedType", err)
+	}
+}
+
+func BenchmarkReadCSV(b *testing.B) {
+	var buf bytes.Buffer
+	buf.WriteString("訂單編號,customer,unit_price,paid,timeout,created\n")
+	for range 1000 {
+		buf.WriteString("1001,王小明,12.5,true,30s,2024-05-20T08:00:00Z\n")
+	}
+	data := buf.Bytes()
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := ReadCSV[csvOrder](bytes.NewReader(data), &CSVOptions{Encoding: bytesconv.UTF8}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,482 @@
+package convert
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidTarget is returned by Decode and LoadEnv when dst is not a
+// non-nil pointer to a struct.
+var ErrInvalidTarget = errors.New("convert: destination must be a non-nil pointer to a struct")
+
+// defaultTagName is the struct tag Decode and Encode read field names from.
+const defaultTagName = "json"
+
+// DecodeOptions configures Decode. A nil *DecodeOptions uses the defaults.
+type DecodeOptions struct {
+	// TagName is the struct tag holding the key for each field. The default
+	// is "json".
+	TagName string
+	// WeaklyTyped retries failed conversions with the lenient ToFloat,
+	// ToBool and ToString, truncating floats toward zero for integer
+	// fields of any width, so "12.9" decodes into an int as 12 and "yes"
+	// into a bool as true.
+	WeaklyTyped bool
+}
+
+// FieldError records why a single field failed to decode. Path is the
+// location in the source map, such as "server.ports[1]".
+type FieldError struct {
+	Path string
+	Err  error
+
+	// key is the top-level source key the failing value came from, which
+	// CSVReader maps back to a column.
+	key string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("convert: field %s: %v", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// FieldErrors lists every field that failed to decode, in source order.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap lets errors.Is and errors.As inspect every field error.
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fe := range e {
+		errs[i] = fe
+	}
+	return errs
+}
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+/*
+Decode copies src into the struct dst points to.
+
+Each field is looked up by its tag name (TagName, "json" by default), then
+by its Go name, then by SnakeCasedName of the Go name, and finally by any
+key whose TitleCasedName equals the Go name. Embedded structs without a tag
+are flattened, nested structs decode from nested maps, and slices, arrays
+and maps decode element by element. Values are converted with To rules, or
+the lenient To* helpers when WeaklyTyped is set; strings decode into
+encoding.TextUnmarshaler fields and time.Duration via time.ParseDuration.
+
+Decoding continues past bad fields. The returned error is a FieldErrors
+listing every failing path.
+
+Example:
+
+	var cfg struct {
+		Port    int `json:"port"`
+		Debug   bool
+		MaxConn int
+	}
+	err := convert.Decode(map[string]any{"port": "8080", "debug": true, "max_conn": 10}, &cfg, nil)
+*/
+func Decode(src map[string]any, dst any, opts *DecodeOptions) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	d := &decoder{tag: defaultTagName}
+	if opts != nil {
+		if opts.TagName != "" {
+			d.tag = opts.TagName
+		}
+		d.weak = opts.WeaklyTyped
+	}
+	d.decodeStruct("", src, rv.Elem())
+	if len(d.errs) > 0 {
+		return d.errs
+	}
+	return nil
+}
+
+type decoder struct {
+	tag  string
+	weak bool
+	// bools, when set, parses strings into bool fields instead of the
+	// strict To rules.
+	bools *BoolProfile
+	errs  FieldErrors
+	// key is the top-level source key being decoded.
+	key string
+}
+
+func (d *decoder) fail(path string, err error) {
+	d.errs = append(d.errs, &FieldError{Path: path, Err: err, key: d.key})
+}
+
+// fieldName returns the tag name of f and whether it carries a tag at all.
+// A "-" tag skips the field.
+func fieldName(f reflect.StructField, tag string) (name string, tagged, skip bool) {
+	v, ok := f.Tag.Lookup(tag)
+	if !ok {
+		return f.Name, false, false
+	}
+	name, _, _ = strings.Cut(v, ",")
+	if name == "-" {
+		return "", true, true
+	}
+	if name == "" {
+		return f.Name, false, false
+	}
+	return name, true, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func (d *decoder) decodeStruct(path string, src map[string]any, out reflect.Value) {
+	// titled maps TitleCasedName(key) to key and is built on first use.
+	var titled map[string]string
+	t := out.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, tagged, skip := fieldName(f, d.tag)
+		if skip {
+			continue
+		}
+		if f.Anonymous && !tagged {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fv := out.Field(i)
+				if fv.Kind() == reflect.Pointer {
+					if fv.IsNil() {
+						if !fv.CanSet() {
+							continue
+						}
+						fv.Set(reflect.New(ft))
+					}
+					fv = fv.Elem()
+				}
+				d.decodeStruct(path, src, fv)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		key, ok := lookupKey(src, name, f.Name)
+		if !ok {
+			if titled == nil {
+				titled = titleKeys(src)
+			}
+			if key, ok = titled[f.Name]; !ok {
+				continue
+			}
+		}
+		if path == "" {
+			d.key = key
+		}
+		d.decodeValue(joinPath(path, key), src[key], out.Field(i))
+	}
+}
+
+// lookupKey finds the source key for a field by tag name, Go name and
+// snake_case Go name.
+func lookupKey(src map[string]any, name, goName string) (string, bool) {
+	for _, key := range [...]string{name, goName, SnakeCasedName(goName)} {
+		if _, ok := src[key]; ok {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func titleKeys(src map[string]any) map[string]string {
+	keys := make([]string, 0, len(src))
+	for k := range src {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	titled := make(map[string]string, len(keys))
+	for _, k := range keys {
+		if _, ok := titled[TitleCasedName(k)]; !ok {
+			titled[TitleCasedName(k)] = k
+		}
+	}
+	return titled
+}
+
+func (d *decoder) decodeValue(path string, in any, out reflect.Value) {
+	if in == nil {
+		out.SetZero()
+		return
+	}
+	iv := reflect.ValueOf(in)
+	if iv.Type().AssignableTo(out.Type()) {
+		out.Set(iv)
+		return
+	}
+	if out.Kind() == reflect.Pointer {
+		n := len(d.errs)
+		elem := reflect.New(out.Type().Elem())
+		d.decodeValue(path, in, elem.Elem())
+		if len(d.errs) == n {
+			out.Set(elem)
+		}
+		return
+	}
+	if s, ok := in.(string); ok {
+		if out.CanAddr() && out.Addr().Type().Implements(textUnmarshalerType) {
+			if err := out.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				d.fail(path, err)
+			}
+			return
+		}
+		if out.Kind() == reflect.Slice && out.Type().Elem().Kind() == reflect.Uint8 {
+			out.SetBytes([]byte(s))
+			return
+		}
+		if out.Type() == durationType {
+			v, err := time.ParseDuration(s)
+			if err != nil {
+				d.fail(path, fmt.Errorf("%w: %w", ErrSyntax, err))
+				return
+			}
+			out.SetInt(int64(v))
+			return
+		}
+	}
+
+	switch out.Kind() {
+	case reflect.Struct:
+		m, ok := in.(map[string]any)
+		if !ok {
+			d.fail(path, fmt.Errorf("%w: cannot decode %T into %v", ErrUnsupportedType, in, out.Type()))
+			return
+		}
+		d.decodeStruct(path, m, out)
+	case reflect.Map:
+		d.decodeMap(path, iv, out)
+	case reflect.Slice, reflect.Array:
+		d.decodeSlice(path, iv, out)
+	case reflect.Interface:
+		if !iv.Type().Implements(out.Type()) {
+			d.fail(path, fmt.Errorf("%w: %T does not implement %v", ErrUnsupportedType, in, out.Type()))
+			return
+		}
+		out.Set(iv)
+	default:
+		v, err := d.convertBasic(in, out.Type())
+		if err != nil {
+			d.fail(path, err)
+			return
+		}
+		out.Set(v)
+	}
+}
+
+func (d *decoder) decodeMap(path string, iv reflect.Value, out reflect.Value) {
+	if iv.Kind() != reflect.Map {
+		d.fail(path, fmt.Errorf("%w: cannot decode %v into %v", ErrUnsupportedType, iv.Type(), out.Type()))
+		return
+	}
+	t := out.Type()
+	if out.IsNil() {
+		out.Set(reflect.MakeMapWithSize(t, iv.Len()))
+	}
+	keys := iv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	for _, k := range keys {
+		keyPath := joinPath(path, fmt.Sprint(k.Interface()))
+		n := len(d.errs)
+		key := reflect.New(t.Key()).Elem()
+		d.decodeValue(keyPath, k.Interface(), key)
+		elem := reflect.New(t.Elem()).Elem()
+		d.decodeValue(keyPath, iv.MapIndex(k).Interface(), elem)
+		// Entries that failed are left out rather than stored as zero values.
+		if len(d.errs) == n {
+			out.SetMapIndex(key, elem)
+		}
+	}
+}
+
+func (d *decoder) decodeSlice(path string, iv reflect.Value, out reflect.Value) {
+	if iv.Kind() != reflect.Slice && iv.Kind() != reflect.Array {
+		d.fail(path, fmt.Errorf("%w: cannot decode %v into %v", ErrUnsupportedType, iv.Type(), out.Type()))
+		return
+	}
+	n := iv.Len()
+	if out.Kind() == reflect.Array {
+		if n > out.Len() {
+			d.fail(path, fmt.Errorf("%w: %d elements do not fit %v", ErrOverflow, n, out.Type()))
+			return
+		}
+	} else {
+		out.Set(reflect.MakeSlice(out.Type(), n, n))
+	}
+	for i := 0; i < n; i++ {
+		d.decodeValue(path+"["+strconv.Itoa(i)+"]", iv.Index(i).Interface(), out.Index(i))
+	}
+}
+
+// convertBasic converts in to a bool, string or numeric type t.
+func (d *decoder) convertBasic(in any, t reflect.Type) (reflect.Value, error) {
+	zero, ok := basicZero[t.Kind()]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: cannot decode %T into %v", ErrUnsupportedType, in, t)
+	}
+	if s, ok := in.(string); ok && d.bools != nil && t.Kind() == reflect.Bool {
+		v, err := d.bools.Parse(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(v).Convert(t), nil
+	}
+	out, err := convertTo(in, zero)
+	if err != nil && d.weak {
+		if lenient := weakValue(in, t.Kind()); lenient != nil {
+			out, err = convertTo(lenient, zero)
+		}
+	}
+	if err != nil {
+		return reflect.Value{}, &ConversionError{Value: in, Target: t, Err: err}
+	}
+	return reflect.ValueOf(out).Convert(t), nil
+}
+
+// weakValue applies the lenient conversion for kind, returning nil when
+// there is none. Only strings accept values that are not scalars.
+func weakValue(in any, kind reflect.Kind) any {
+	if _, ok := toScalar(in); !ok && kind != reflect.String {
+		return nil
+	}
+	switch kind {
+	case reflect.Bool:
+		return ToBool(in)
+	case reflect.String:
+		return ToString(in)
+	case reflect.Float32, reflect.Float64:
+		return ToFloat(in)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// ToInt is limited to the int32 range, so go through float64 and
+		// let convertTo check the range of the actual target.
+		switch f := ToFloat(in).(type) {
+		case float64:
+			return math.Trunc(f)
+		case float32:
+			return math.Trunc(float64(f))
+		}
+	}
+	return nil
+}
+
+/*
+Encode converts the struct src, or a pointer to one, into a map keyed by
+each field's json tag name, or its Go name when there is no tag. Embedded
+structs without a tag are flattened, nested structs become nested maps,
+and slices and maps of structs are converted element by element. Fields
+tagged "-" are skipped and fields tagged omitempty are left out when
+empty. Values implementing encoding.TextMarshaler, such as time.Time, are
+kept as they are. Encode returns nil if src is not a struct.
+*/
+func Encode(src any) map[string]any {
+	rv, ok := indirect(reflect.ValueOf(src))
+	if !ok || rv.Kind() != reflect.Struct {
+		return nil
+	}
+	out := make(map[string]any, rv.NumField())
+	encodeStruct(rv, out)
+	return out
+}
+
+func encodeStruct(rv reflect.Value, out map[string]any) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, tagged, skip := fieldName(f, defaultTagName)
+		if skip {
+			continue
+		}
+		fv := rv.Field(i)
+		if f.Anonymous && !tagged {
+			if ev, ok := indirect(fv); ok && ev.Kind() == reflect.Struct {
+				encodeStruct(ev, out)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if strings.Contains(f.Tag.Get(defaultTagName), ",omitempty") && fv.IsZero() {
+			continue
+		}
+		out[name] = encodeValue(fv)
+	}
+}
+
+func encodeValue(v reflect.Value) any {
+	if v.Type().Implements(textMarshalerType) {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return encodeValue(v.Elem())
+	case reflect.Struct:
+		m := make(map[string]any, v.NumField())
+		encodeStruct(v, m)
+		return m
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		s := make([]any, v.Len())
+		for i := range s {
+			s[i] = encodeValue(v.Index(i))
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = encodeValue(iter.Value())
+		}
+		return m
+	}
+	return v.Interface()
+}
@@ -0,0 +1,276 @@
+package convert
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type decodeBase struct {
+	ID      int64  `json:"id"`
+	Created string `json:"created"`
+}
+
+type decodeServer struct {
+	Host    string        `json:"host"`
+	Ports   []uint16      `json:"ports"`
+	Timeout time.Duration `json:"timeout"`
+}
+
+type decodeConfig struct {
+	decodeBase
+	Name     string            `json:"name"`
+	Debug    bool              `json:"debug"`
+	MaxConn  int               // matched as max_conn
+	UserName string            // matched as user_name
+	Ratio    *float64          `json:"ratio"`
+	Server   decodeServer      `json:"server"`
+	Backups  []decodeServer    `json:"backups"`
+	Labels   map[string]string `json:"labels"`
+	Addr     netip.Addr        `json:"addr"`
+	Extra    any               `json:"extra"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func TestDecode(t *testing.T) {
+	src := map[string]any{
+		"id":        float64(7), // encoding/json decodes numbers as float64
+		"created":   "2024-01-02",
+		"name":      "api",
+		"debug":     "true",
+		"max_conn":  "128",
+		"user_name": "root",
+		"ratio":     0.5,
+		"server": map[string]any{
+			"host":    "localhost",
+			"ports":   []any{80, "443"},
+			"timeout": "1m30s",
+		},
+		"backups": []any{
+			map[string]any{"host": "b1"},
+			map[string]any{"host": "b2", "ports": []int{8080}},
+		},
+		"labels":   map[string]any{"env": "prod", "tier": 1},
+		"addr":     "192.0.2.1",
+		"extra":    []any{1, "x"},
+		"Ignored":  "nope",
+		"internal": "nope",
+	}
+	var cfg decodeConfig
+	if err := Decode(src, &cfg, nil); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	ratio := 0.5
+	want := decodeConfig{
+		decodeBase: decodeBase{ID: 7, Created: "2024-01-02"},
+		Name:       "api",
+		Debug:      true,
+		MaxConn:    128,
+		UserName:   "root",
+		Ratio:      &ratio,
+		Server:     decodeServer{Host: "localhost", Ports: []uint16{80, 443}, Timeout: 90 * time.Second},
+		Backups:    []decodeServer{{Host: "b1"}, {Host: "b2", Ports: []uint16{8080}}},
+		Labels:     map[string]string{"env": "prod", "tier": "1"},
+		Addr:       netip.MustParseAddr("192.0.2.1"),
+		Extra:      []any{1, "x"},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Decode() =\n%+v\nwant\n%+v", cfg, want)
+	}
+}
+
+func TestDecodeTitleCasedKeys(t *testing.T) {
+	var dst struct {
+		MaxConn int
+		Name    string `yaml:"title"`
+	}
+	src := map[string]any{"max_conn": 5, "title": "x"}
+	if err := Decode(src, &dst, &DecodeOptions{TagName: "yaml"}); err != nil {
+		t.Fatal(err)
+	}
+	if dst.MaxConn != 5 || dst.Name != "x" {
+		t.Errorf("Decode() = %+v", dst)
+	}
+
+	var titled struct{ HTTPPort int }
+	if err := Decode(map[string]any{"HTTP_port": 1}, &titled, nil); err != nil || titled.HTTPPort != 1 {
+		t.Errorf("Decode() = %+v, %v", titled, err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	src := map[string]any{
+		"name":  "ok",
+		"debug": "yes",
+		"server": map[string]any{
+			"ports":   []any{80, 70000, "x"},
+			"timeout": "soon",
+		},
+		"backups": "not a list",
+		"addr":    "999.0.0.1",
+	}
+	var cfg decodeConfig
+	err := Decode(src, &cfg, nil)
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("Decode() error = %v, want FieldErrors", err)
+	}
+	var paths []string
+	for _, fe := range fieldErrs {
+		paths = append(paths, fe.Path)
+	}
+	want := []string{"debug", "server.ports[1]", "server.ports[2]", "server.timeout", "backups", "addr"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("failing paths = %v, want %v", paths, want)
+	}
+	if !errors.Is(err, ErrOverflow) || !errors.Is(err, ErrSyntax) || !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Decode() error does not wrap the field causes: %v", err)
+	}
+	if !strings.Contains(err.Error(), "convert: field server.ports[1]: ") {
+		t.Errorf("Decode() error message = %q", err.Error())
+	}
+	if cfg.Name != "ok" {
+		t.Errorf("valid fields should still decode, Name = %q", cfg.Name)
+	}
+}
+
+func TestDecodeWeaklyTyped(t *testing.T) {
+	var dst struct {
+		Count  int     `json:"count"`
+		Ratio  float32 `json:"ratio"`
+		Active bool    `json:"active"`
+		Label  string  `json:"label"`
+		Level  uint8   `json:"level"`
+	}
+	src := map[string]any{"count": "12.9", "ratio": true, "active": "yes", "label": []int{1, 2}, "level": "3.0"}
+	if err := Decode(src, &dst, nil); err == nil {
+		t.Fatal("strict Decode() should fail")
+	}
+	if err := Decode(src, &dst, &DecodeOptions{WeaklyTyped: true}); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if dst.Count != 12 || dst.Ratio != 1 || !dst.Active || dst.Label != "[1 2]" || dst.Level != 3 {
+		t.Errorf("Decode() = %+v", dst)
+	}
+
+	// Integer fields use their own width, not the int32 range of ToInt.
+	var wide struct {
+		Big  int64
+		UBig uint64
+		Neg  uint8
+	}
+	err := Decode(map[string]any{"big": "3000000000.5", "u_big": 5e9, "neg": "-1.5"}, &wide, &DecodeOptions{WeaklyTyped: true})
+	if wide.Big != 3000000000 || wide.UBig != 5000000000 || !errors.Is(err, ErrOverflow) {
+		t.Errorf("Decode() = %+v, %v", wide, err)
+	}
+}
+
+func TestDecodeFailedEntriesNotStored(t *testing.T) {
+	var dst struct {
+		Names map[int]string
+		Sizes map[string]int
+		Ptr   *int
+		Keep  *int
+	}
+	keep := 7
+	dst.Keep = &keep
+	src := map[string]any{
+		"names": map[string]any{"x": "a", "1": "b"},
+		"sizes": map[string]any{"ok": 1, "bad": "big"},
+		"ptr":   "nope",
+		"keep":  "nope",
+	}
+	err := Decode(src, &dst, nil)
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) || len(fieldErrs) != 4 {
+		t.Fatalf("Decode() error = %v, want 4 field errors", err)
+	}
+	if !reflect.DeepEqual(dst.Names, map[int]string{1: "b"}) || !reflect.DeepEqual(dst.Sizes, map[string]int{"ok": 1}) {
+		t.Errorf("maps = %v %v, want failed entries left out", dst.Names, dst.Sizes)
+	}
+	if dst.Ptr != nil || dst.Keep != &keep || keep != 7 {
+		t.Errorf("pointers = %v %v, want unchanged on failure", dst.Ptr, dst.Keep)
+	}
+}
+
+func TestDecodeInvalidTarget(t *testing.T) {
+	var n int
+	for _, dst := range []any{nil, decodeConfig{}, &n, (*decodeConfig)(nil)} {
+		if err := Decode(map[string]any{}, dst, nil); !errors.Is(err, ErrInvalidTarget) {
+			t.Errorf("Decode(%T) error = %v, want ErrInvalidTarget", dst, err)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	ratio := 0.25
+	when := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	src := struct {
+		decodeBase
+		Name    string            `json:"name"`
+		Empty   string            `json:"empty,omitempty"`
+		Ratio   *float64          `json:"ratio"`
+		Nil     *int              `json:"nil"`
+		Server  decodeServer      `json:"server"`
+		Backups []decodeServer    `json:"backups"`
+		Labels  map[string]string `json:"labels"`
+		When    time.Time         `json:"when"`
+		Skip    int               `json:"-"`
+		Plain   int
+		hidden  int
+	}{
+		decodeBase: decodeBase{ID: 1, Created: "c"},
+		Name:       "n",
+		Ratio:      &ratio,
+		Server:     decodeServer{Host: "h", Ports: []uint16{1}},
+		Backups:    []decodeServer{{Host: "b"}},
+		Labels:     map[string]string{"k": "v"},
+		When:       when,
+		Skip:       1,
+		Plain:      2,
+		hidden:     3,
+	}
+	want := map[string]any{
+		"id":      int64(1),
+		"created": "c",
+		"name":    "n",
+		"ratio":   0.25,
+		"nil":     nil,
+		"server":  map[string]any{"host": "h", "ports": []any{uint16(1)}, "timeout": time.Duration(0)},
+		"backups": []any{map[string]any{"host": "b", "ports": nil, "timeout": time.Duration(0)}},
+		"labels":  map[string]any{"k": "v"},
+		"when":    when,
+		"Plain":   2,
+	}
+	if got := Encode(&src); !reflect.DeepEqual(got, want) {
+		t.Errorf("Encode() =\n%#v\nwant\n%#v", got, want)
+	}
+	if got := Encode(42); got != nil {
+		t.Errorf("Encode(42) = %v, want nil", got)
+	}
+	_ = src.hidden
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	ratio := 1.5
+	in := decodeConfig{
+		decodeBase: decodeBase{ID: 3},
+		Name:       "rt",
+		MaxConn:    9,
+		Ratio:      &ratio,
+		Server:     decodeServer{Host: "h", Ports: []uint16{1, 2}, Timeout: time.Second},
+		Labels:     map[string]string{"a": "b"},
+		Addr:       netip.MustParseAddr("::1"),
+	}
+	var out decodeConfig
+	if err := Decode(Encode(in), &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
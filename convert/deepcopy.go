@@ -0,0 +1,121 @@
+package convert
+
+import "reflect"
+
+/*
+DeepCopy returns a copy of v that shares no pointers, slices or maps with it,
+so changing the copy never changes v. It is meant for pointer-heavy structs,
+such as API request types built with ToPtr.
+
+Values reached through more than one path, including cycles, are copied once
+and the copy keeps the same shape. Unexported struct fields, channels and
+functions are copied shallowly, because reflection cannot set them or they
+have no meaningful copy.
+
+Example:
+
+	type Req struct {
+		Name *string
+		Tags []string
+	}
+
+	a := Req{Name: convert.ToPtr("a"), Tags: []string{"x"}}
+	b := convert.DeepCopy(a)
+	*b.Name = "b" // a.Name is still "a"
+*/
+func DeepCopy[T any](v T) T {
+	var out T
+	c := copier{seen: make(map[seenKey]reflect.Value)}
+	c.copy(reflect.ValueOf(&out).Elem(), reflect.ValueOf(&v).Elem())
+	return out
+}
+
+// seenKey identifies a pointer, map or slice already copied. The type is
+// part of the key because a struct and its first field share an address,
+// and the length because slices of one array may differ.
+type seenKey struct {
+	addr uintptr
+	typ  reflect.Type
+	len  int
+}
+
+type copier struct {
+	seen map[seenKey]reflect.Value
+}
+
+// copy sets dst, a settable value of src's type, to a deep copy of src.
+func (c *copier) copy(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		key := seenKey{addr: src.Pointer(), typ: src.Type()}
+		if p, ok := c.seen[key]; ok {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		c.seen[key] = p
+		c.copy(p.Elem(), src.Elem())
+		dst.Set(p)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := src.Elem()
+		cp := reflect.New(elem.Type()).Elem()
+		c.copy(cp, elem)
+		dst.Set(cp)
+	case reflect.Struct:
+		// Start from a shallow copy so unexported fields keep their values.
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if f := dst.Field(i); f.CanSet() {
+				c.copy(f, src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		key := seenKey{addr: src.Pointer(), typ: src.Type(), len: src.Len()}
+		if s, ok := c.seen[key]; ok {
+			dst.Set(s)
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		c.seen[key] = s
+		for i := 0; i < src.Len(); i++ {
+			c.copy(s.Index(i), src.Index(i))
+		}
+		dst.Set(s)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.copy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := seenKey{addr: src.Pointer(), typ: src.Type()}
+		if m, ok := c.seen[key]; ok {
+			dst.Set(m)
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.seen[key] = m
+		kt, vt := src.Type().Key(), src.Type().Elem()
+		iter := src.MapRange()
+		for iter.Next() {
+			k := reflect.New(kt).Elem()
+			c.copy(k, iter.Key())
+			val := reflect.New(vt).Elem()
+			c.copy(val, iter.Value())
+			m.SetMapIndex(k, val)
+		}
+		dst.Set(m)
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,141 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type deepAddress struct {
+	City *string
+	Tags map[string][]int
+}
+
+type deepUser struct {
+	Name     *string
+	Age      *int
+	Emails   []string
+	Address  *deepAddress
+	Extra    any
+	Matrix   [2][]int
+	Created  time.Time
+	internal *int
+}
+
+type deepNode struct {
+	Value int
+	Next  *deepNode
+	Peers []*deepNode
+}
+
+func TestDeepCopy(t *testing.T) {
+	hidden := 1
+	src := deepUser{
+		Name:   ToPtr("alice"),
+		Age:    ToPtr(30),
+		Emails: []string{"a@example.com"},
+		Address: &deepAddress{
+			City: ToPtr("Taipei"),
+			Tags: map[string][]int{"a": {1, 2}},
+		},
+		Extra:    map[string]any{"k": []any{"v"}},
+		Matrix:   [2][]int{{1}, {2}},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		internal: &hidden,
+	}
+	dst := DeepCopy(src)
+	if !reflect.DeepEqual(src, dst) {
+		t.Fatalf("DeepCopy() = %+v, want %+v", dst, src)
+	}
+
+	*dst.Name = "bob"
+	*dst.Age = 31
+	dst.Emails[0] = "b@example.com"
+	*dst.Address.City = "Tainan"
+	dst.Address.Tags["a"][0] = 100
+	dst.Extra.(map[string]any)["k"].([]any)[0] = "changed"
+	dst.Matrix[0][0] = 100
+
+	if *src.Name != "alice" || *src.Age != 30 || src.Emails[0] != "a@example.com" {
+		t.Errorf("top level fields were shared: %+v", src)
+	}
+	if *src.Address.City != "Taipei" || src.Address.Tags["a"][0] != 1 {
+		t.Errorf("nested pointer fields were shared: %+v", src.Address)
+	}
+	if src.Extra.(map[string]any)["k"].([]any)[0] != "v" {
+		t.Errorf("interface value was shared: %v", src.Extra)
+	}
+	if src.Matrix[0][0] != 1 {
+		t.Errorf("array of slices was shared: %v", src.Matrix)
+	}
+	if dst.internal != src.internal {
+		t.Errorf("unexported field should be copied shallowly")
+	}
+}
+
+func TestDeepCopyNil(t *testing.T) {
+	if got := DeepCopy[*deepUser](nil); got != nil {
+		t.Errorf("DeepCopy(nil) = %v, want nil", got)
+	}
+	var m map[string]int
+	if got := DeepCopy(m); got != nil {
+		t.Errorf("DeepCopy(nil map) = %v, want nil", got)
+	}
+	var s []int
+	if got := DeepCopy(s); got != nil {
+		t.Errorf("DeepCopy(nil slice) = %v, want nil", got)
+	}
+	var v any
+	if got := DeepCopy(v); got != nil {
+		t.Errorf("DeepCopy(nil interface) = %v, want nil", got)
+	}
+}
+
+func TestDeepCopyCycle(t *testing.T) {
+	a := &deepNode{Value: 1}
+	b := &deepNode{Value: 2, Next: a}
+	a.Next = b
+	a.Peers = []*deepNode{a, b}
+
+	c := DeepCopy(a)
+	if c == a || c.Next == b {
+		t.Fatal("DeepCopy() returned shared nodes")
+	}
+	if c.Next.Next != c {
+		t.Error("cycle a -> b -> a was not preserved")
+	}
+	if c.Peers[0] != c || c.Peers[1] != c.Next {
+		t.Error("shared pointers were copied more than once")
+	}
+	c.Next.Value = 20
+	if b.Value != 2 {
+		t.Errorf("b.Value = %d, want 2", b.Value)
+	}
+}
+
+func TestDeepCopySelfMap(t *testing.T) {
+	m := map[string]any{"n": 1}
+	m["self"] = m
+	c := DeepCopy(m)
+	c["n"] = 2
+	if m["n"] != 1 {
+		t.Errorf("m[n] = %v, want 1", m["n"])
+	}
+	self, ok := c["self"].(map[string]any)
+	if !ok || reflect.ValueOf(self).Pointer() != reflect.ValueOf(c).Pointer() {
+		t.Error("self reference should point to the copy")
+	}
+}
+
+func BenchmarkDeepCopy(b *testing.B) {
+	src := deepUser{
+		Name:    ToPtr("alice"),
+		Age:     ToPtr(30),
+		Emails:  []string{"a@example.com", "b@example.com"},
+		Address: &deepAddress{City: ToPtr("Taipei"), Tags: map[string][]int{"a": {1, 2}}},
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = DeepCopy(src)
+	}
+}
@@ -0,0 +1,190 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrMissingEnv is reported for a required field whose variable is not
+	// set and has no default.
+	ErrMissingEnv = errors.New("required environment variable is not set")
+	// ErrAmbiguousEnv is reported when both NAME and NAME_FILE are set.
+	ErrAmbiguousEnv = errors.New("both the variable and its _FILE variant are set")
+)
+
+/*
+LoadEnv fills the struct dst points to from environment variables.
+
+The variable for a field is prefix, an underscore, and the field's name in
+upper snake case (SnakeCasedName uppercased), so with prefix "APP" the
+field MaxConn reads APP_MAX_CONN. Nested structs add their own name to the
+prefix and embedded structs are flattened. A nil pointer to a nested struct
+is only allocated when one of its variables is set, so optional sections
+stay nil. Field tags control the rest:
+
+	Port    int           `env:"PORT,required"` // explicit name, must be set
+	Hosts   []string      `env:"HOSTS" sep:";"`  // split on ";" instead of ","
+	Timeout time.Duration `default:"30s"`        // used when the variable is unset
+	Secret  string        `env:"-"`              // ignored
+
+If NAME_FILE is set instead of NAME, the value is read from that file with
+trailing newlines removed, which suits Docker and Kubernetes secrets.
+Values are converted with To rules, booleans with BoolEnv and durations
+with time.ParseDuration. Every failure is collected, and the returned error
+is a FieldErrors keyed by variable name.
+*/
+func LoadEnv(dst any, prefix string) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+	d := &decoder{bools: BoolEnv}
+	d.loadEnvStruct(prefix, rv.Elem())
+	if len(d.errs) > 0 {
+		return d.errs
+	}
+	return nil
+}
+
+// loadEnvStruct fills out and reports whether any of its variables, or
+// those of a nested struct, were set in the environment.
+func (d *decoder) loadEnvStruct(prefix string, out reflect.Value) bool {
+	found := false
+	t := out.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("env")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		fv := out.Field(i)
+		if f.Anonymous && !hasTag && isEnvStruct(f.Type) {
+			found = d.loadEnvSection(prefix, fv) || found
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToUpper(SnakeCasedName(f.Name))
+		}
+		key := prefix + name
+		if isEnvStruct(f.Type) {
+			found = d.loadEnvSection(key+"_", fv) || found
+			continue
+		}
+
+		value, ok, err := lookupEnvFile(key)
+		if err != nil {
+			d.fail(key, err)
+			found = true
+			continue
+		}
+		found = found || ok
+		if !ok {
+			value, ok = f.Tag.Lookup("default")
+		}
+		if !ok {
+			if hasOption(opts, "required") {
+				d.fail(key, ErrMissingEnv)
+			}
+			continue
+		}
+		d.decodeValue(key, envValue(value, f), fv)
+	}
+	return found
+}
+
+// isEnvStruct reports whether t is a struct, or a pointer to one, that
+// LoadEnv should descend into rather than decode from a single variable.
+func isEnvStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// loadEnvSection loads the struct v holds. A nil pointer is only allocated
+// when one of the section's variables is set, so optional sections stay nil
+// and their required fields are not reported.
+func (d *decoder) loadEnvSection(prefix string, v reflect.Value) bool {
+	if v.Kind() != reflect.Pointer {
+		return d.loadEnvStruct(prefix, v)
+	}
+	if !v.IsNil() {
+		return d.loadEnvStruct(prefix, v.Elem())
+	}
+	if !v.CanSet() {
+		return false
+	}
+	n := len(d.errs)
+	section := reflect.New(v.Type().Elem())
+	if !d.loadEnvStruct(prefix, section.Elem()) {
+		d.errs = d.errs[:n]
+		return false
+	}
+	v.Set(section)
+	return true
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupEnvFile reads key from the environment, or from the file named by
+// key_FILE.
+func lookupEnvFile(key string) (string, bool, error) {
+	value, ok := os.LookupEnv(key)
+	path, fileOK := os.LookupEnv(key + "_FILE")
+	switch {
+	case ok && fileOK:
+		return "", false, ErrAmbiguousEnv
+	case !fileOK:
+		return value, ok, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("read %s_FILE: %w", key, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// envValue splits value on the field's separator for slice fields, which
+// default to ",". Other fields get value unchanged.
+func envValue(value string, f reflect.StructField) any {
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		return value
+	}
+	if value == "" {
+		return []any{}
+	}
+	sep, ok := f.Tag.Lookup("sep")
+	if !ok {
+		sep = ","
+	}
+	parts := strings.Split(value, sep)
+	items := make([]any, len(parts))
+	for i, p := range parts {
+		items[i] = strings.TrimSpace(p)
+	}
+	return items
+}
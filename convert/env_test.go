@@ -0,0 +1,144 @@
+package convert
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type envDatabase struct {
+	Host     string `default:"localhost"`
+	Port     uint16 `default:"5432"`
+	Password string `env:"PASSWORD,required"`
+}
+
+type envCommon struct {
+	LogLevel string `default:"info"`
+}
+
+type envConfig struct {
+	envCommon
+	Name     string `env:"NAME,required"`
+	Debug    bool
+	MaxConn  int           `default:"10"`
+	Timeout  time.Duration `default:"30s"`
+	Hosts    []string
+	Ports    []int `sep:";"`
+	Ratio    *float64
+	Addr     netip.Addr
+	Key      []byte
+	Database envDatabase `env:"DB"`
+	Cache    *envDatabase
+	Skipped  string `env:"-"`
+}
+
+func TestLoadEnv(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "password")
+	if err := os.WriteFile(secret, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("APP_NAME", "api")
+	t.Setenv("APP_DEBUG", "on")
+	t.Setenv("APP_TIMEOUT", "1m")
+	t.Setenv("APP_HOSTS", "a, b ,c")
+	t.Setenv("APP_PORTS", "80;443")
+	t.Setenv("APP_RATIO", "0.5")
+	t.Setenv("APP_ADDR", "192.0.2.1")
+	t.Setenv("APP_KEY", "raw")
+	t.Setenv("APP_LOG_LEVEL", "debug")
+	t.Setenv("APP_DB_HOST", "db.internal")
+	t.Setenv("APP_DB_PASSWORD_FILE", secret)
+	t.Setenv("APP_CACHE_PASSWORD", "c")
+	t.Setenv("APP_SKIPPED", "x")
+
+	var cfg envConfig
+	if err := LoadEnv(&cfg, "APP"); err != nil {
+		t.Fatalf("LoadEnv() error = %v", err)
+	}
+	ratio := 0.5
+	want := envConfig{
+		envCommon: envCommon{LogLevel: "debug"},
+		Name:      "api",
+		Debug:     true,
+		MaxConn:   10,
+		Timeout:   time.Minute,
+		Hosts:     []string{"a", "b", "c"},
+		Ports:     []int{80, 443},
+		Ratio:     &ratio,
+		Addr:      netip.MustParseAddr("192.0.2.1"),
+		Key:       []byte("raw"),
+		Database:  envDatabase{Host: "db.internal", Port: 5432, Password: "s3cret"},
+		Cache:     &envDatabase{Host: "localhost", Port: 5432, Password: "c"},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadEnv() =\n%+v\nwant\n%+v", cfg, want)
+	}
+}
+
+func TestLoadEnvErrors(t *testing.T) {
+	t.Setenv("APP_DEBUG", "sometimes")
+	t.Setenv("APP_MAX_CONN", "lots")
+	t.Setenv("APP_PORTS", "80;x")
+	t.Setenv("APP_DB_PASSWORD", "a")
+	t.Setenv("APP_DB_PASSWORD_FILE", "/dev/null")
+	t.Setenv("APP_CACHE_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	var cfg envConfig
+	err := LoadEnv(&cfg, "APP_")
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("LoadEnv() error = %v, want FieldErrors", err)
+	}
+	var keys []string
+	for _, fe := range fieldErrs {
+		keys = append(keys, fe.Path)
+	}
+	want := []string{"APP_NAME", "APP_DEBUG", "APP_MAX_CONN", "APP_PORTS[1]", "APP_DB_PASSWORD", "APP_CACHE_PASSWORD"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("failing variables = %v, want %v", keys, want)
+	}
+	for _, target := range []error{ErrMissingEnv, ErrAmbiguousEnv, ErrSyntax, os.ErrNotExist} {
+		if !errors.Is(err, target) {
+			t.Errorf("LoadEnv() error does not wrap %v", target)
+		}
+	}
+}
+
+func TestLoadEnvOptionalSection(t *testing.T) {
+	t.Setenv("APP_NAME", "api")
+	t.Setenv("APP_DB_PASSWORD", "p")
+
+	// Defaults alone do not allocate the section, and its required fields
+	// are not reported while it is absent.
+	var cfg envConfig
+	if err := LoadEnv(&cfg, "APP"); err != nil {
+		t.Fatalf("LoadEnv() error = %v", err)
+	}
+	if cfg.Cache != nil {
+		t.Errorf("Cache = %+v, want nil", cfg.Cache)
+	}
+
+	t.Setenv("APP_CACHE_PORT", "6379")
+	err := LoadEnv(&cfg, "APP")
+	if !errors.Is(err, ErrMissingEnv) || cfg.Cache == nil || cfg.Cache.Port != 6379 {
+		t.Errorf("partial section = %+v, %v, want ErrMissingEnv", cfg.Cache, err)
+	}
+	cfg.Cache = nil
+	t.Setenv("APP_CACHE_PASSWORD", "c")
+	if err := LoadEnv(&cfg, "APP"); err != nil || cfg.Cache == nil || cfg.Cache.Port != 6379 {
+		t.Errorf("Cache = %+v, %v", cfg.Cache, err)
+	}
+}
+
+func TestLoadEnvInvalidTarget(t *testing.T) {
+	var cfg envConfig
+	if err := LoadEnv(cfg, ""); !errors.Is(err, ErrInvalidTarget) {
+		t.Errorf("LoadEnv() error = %v, want ErrInvalidTarget", err)
+	}
+}
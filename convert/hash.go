@@ -0,0 +1,214 @@
+package convert
+
+import (
+	"crypto/hmac"
+	"crypto/md5"  // #nosec G501 -- offered for checksums and legacy protocols
+	"crypto/sha1" // #nosec G505 -- offered for checksums and legacy protocols
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"os"
+)
+
+var (
+	// ErrUnknownHash is returned for a HashAlgo outside the defined set.
+	ErrUnknownHash = errors.New("convert: unknown hash algorithm")
+	// ErrHMACUnsupported is returned when HMAC is asked for with a
+	// non-cryptographic hash.
+	ErrHMACUnsupported = errors.New("convert: HMAC requires a cryptographic hash")
+)
+
+// HashAlgo identifies a hash algorithm supported by Hash.
+type HashAlgo int
+
+// Supported hash algorithms. MD5 and SHA1 are broken for security use and
+// are only offered for checksums and legacy protocols. FNV1a32, FNV1a64
+// and XXH64 are fast non-cryptographic hashes for sharding and hash tables.
+const (
+	MD5 HashAlgo = iota + 1
+	SHA1
+	SHA224
+	SHA256
+	SHA384
+	SHA512
+	SHA3x224
+	SHA3x256
+	SHA3x384
+	SHA3x512
+	BLAKE2s256
+	BLAKE2b256
+	BLAKE2b384
+	BLAKE2b512
+	FNV1a32
+	FNV1a64
+	XXH64
+)
+
+var hashAlgos = [...]struct {
+	name   string
+	new    func() hash.Hash
+	crypto bool
+}{
+	MD5:        {"MD5", md5.New, true},
+	SHA1:       {"SHA-1", sha1.New, true},
+	SHA224:     {"SHA-224", sha256.New224, true},
+	SHA256:     {"SHA-256", sha256.New, true},
+	SHA384:     {"SHA-384", sha512.New384, true},
+	SHA512:     {"SHA-512", sha512.New, true},
+	SHA3x224:   {"SHA3-224", func() hash.Hash { return sha3.New224() }, true},
+	SHA3x256:   {"SHA3-256", func() hash.Hash { return sha3.New256() }, true},
+	SHA3x384:   {"SHA3-384", func() hash.Hash { return sha3.New384() }, true},
+	SHA3x512:   {"SHA3-512", func() hash.Hash { return sha3.New512() }, true},
+	BLAKE2s256: {"BLAKE2s-256", func() hash.Hash { return newBlake2s(32) }, true},
+	BLAKE2b256: {"BLAKE2b-256", func() hash.Hash { return newBlake2b(32) }, true},
+	BLAKE2b384: {"BLAKE2b-384", func() hash.Hash { return newBlake2b(48) }, true},
+	BLAKE2b512: {"BLAKE2b-512", func() hash.Hash { return newBlake2b(64) }, true},
+	FNV1a32:    {"FNV-1a-32", func() hash.Hash { return fnv.New32a() }, false},
+	FNV1a64:    {"FNV-1a-64", func() hash.Hash { return fnv.New64a() }, false},
+	XXH64:      {"XXH64", func() hash.Hash { return NewXXH64(0) }, false},
+}
+
+// Available reports whether a is one of the defined algorithms.
+func (a HashAlgo) Available() bool {
+	return a > 0 && int(a) < len(hashAlgos)
+}
+
+// Cryptographic reports whether a is a cryptographic hash suitable for
+// HMAC.
+func (a HashAlgo) Cryptographic() bool {
+	return a.Available() && hashAlgos[a].crypto
+}
+
+func (a HashAlgo) String() string {
+	if !a.Available() {
+		return fmt.Sprintf("HashAlgo(%d)", int(a))
+	}
+	return hashAlgos[a].name
+}
+
+// New returns a new hash.Hash computing a, or nil if a is not available.
+func (a HashAlgo) New() hash.Hash {
+	if !a.Available() {
+		return nil
+	}
+	return hashAlgos[a].new()
+}
+
+// Digest is the output of a hash function, with helpers for its common
+// text encodings.
+type Digest []byte
+
+// Hex returns the lower case hexadecimal encoding of d.
+func (d Digest) Hex() string { return hex.EncodeToString(d) }
+
+// Base64 returns the standard padded base64 encoding of d.
+func (d Digest) Base64() string { return base64.StdEncoding.EncodeToString(d) }
+
+// Base64URL returns the unpadded URL-safe base64 encoding of d.
+func (d Digest) Base64URL() string { return base64.RawURLEncoding.EncodeToString(d) }
+
+// Base32 returns the standard padded base32 encoding of d.
+func (d Digest) Base32() string { return base32.StdEncoding.EncodeToString(d) }
+
+// String returns d in hexadecimal.
+func (d Digest) String() string { return d.Hex() }
+
+/*
+Hash returns the digest of data computed with algo.
+
+Example:
+
+	d, _ := convert.Hash(convert.SHA256, []byte("hello"))
+	fmt.Println(d.Hex()) // 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+*/
+func Hash(algo HashAlgo, data []byte) (Digest, error) {
+	h := algo.New()
+	if h == nil {
+		return nil, ErrUnknownHash
+	}
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// HashString returns the digest of s computed with algo.
+func HashString(algo HashAlgo, s string) (Digest, error) {
+	h := algo.New()
+	if h == nil {
+		return nil, ErrUnknownHash
+	}
+	_, _ = io.WriteString(h, s)
+	return h.Sum(nil), nil
+}
+
+// HashReader returns the digest of everything read from r, streaming it
+// through the hash instead of buffering it.
+func HashReader(algo HashAlgo, r io.Reader) (Digest, error) {
+	h := algo.New()
+	if h == nil {
+		return nil, ErrUnknownHash
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// HashFile returns the digest of the file at path.
+func HashFile(algo HashAlgo, path string) (Digest, error) {
+	if !algo.Available() {
+		return nil, ErrUnknownHash
+	}
+	f, err := os.Open(path) // #nosec G304 -- hashing a caller supplied path is the purpose
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return HashReader(algo, f)
+}
+
+// HMAC returns the HMAC of data keyed with key, using a cryptographic
+// algo.
+func HMAC(algo HashAlgo, key, data []byte) (Digest, error) {
+	if !algo.Available() {
+		return nil, ErrUnknownHash
+	}
+	if !algo.Cryptographic() {
+		return nil, ErrHMACUnsupported
+	}
+	mac := hmac.New(algo.New, key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+// VerifyHMAC reports whether mac is the HMAC of data keyed with key. The
+// comparison takes constant time, so it does not leak how much of mac
+// matched.
+func VerifyHMAC(algo HashAlgo, key, data, mac []byte) bool {
+	expected, err := HMAC(algo, key, data)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, mac)
+}
+
+// FNV1a32Sum returns the 32-bit FNV-1a hash of data.
+func FNV1a32Sum(data []byte) uint32 {
+	h := fnv.New32a()
+	h.Write(data)
+	return h.Sum32()
+}
+
+// FNV1a64Sum returns the 64-bit FNV-1a hash of data.
+func FNV1a64Sum(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	return h.Sum64()
+}
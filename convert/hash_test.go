@@ -0,0 +1,268 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var hashVectors = []struct {
+	algo HashAlgo
+	in   string
+	want string
+}{
+	{MD5, "abc", "900150983cd24fb0d6963f7d28e17f72"},
+	{SHA1, "abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+	{SHA224, "abc", "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7"},
+	{SHA256, "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	{SHA384, "abc", "cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7"},
+	{SHA512, "abc", "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+	{SHA3x224, "abc", "e642824c3f8cf24ad09234ee7d3c766fc9a3a5168d0c94ad73b46fdf"},
+	{SHA3x256, "abc", "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"},
+	{SHA3x384, "abc", "ec01498288516fc926459f58e2c6ad8df9b473cb0fc08c2596da7cf0e49be4b298d88cea927ac7f539f1edf228376d25"},
+	{SHA3x512, "abc", "b751850b1a57168a5693cd924b6b096e08f621827444f70d884f5d0240d2712e10e116e9192af3c91a7ec57647e3934057340b4cf408d5a56592f8274eec53f0"},
+	{BLAKE2s256, "", "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"},
+	{BLAKE2s256, "abc", "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+	{BLAKE2b256, "", "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+	{BLAKE2b512, "", "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce"},
+	{BLAKE2b512, "abc", "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+	{FNV1a32, "", "811c9dc5"},
+	{FNV1a32, "a", "e40c292c"},
+	{FNV1a64, "", "cbf29ce484222325"},
+	{FNV1a64, "a", "af63dc4c8601ec8c"},
+	{XXH64, "", "ef46db3751d8e999"},
+	{XXH64, "a", "d24ec4f1a98c6e5b"},
+	{XXH64, "abc", "44bc2cf5ad770999"},
+	{XXH64, "Nobody inspects the spammish repetition", "fbcea83c8a378bf1"},
+}
+
+func TestHashVectors(t *testing.T) {
+	for _, tt := range hashVectors {
+		t.Run(tt.algo.String()+"/"+tt.in, func(t *testing.T) {
+			d, err := Hash(tt.algo, []byte(tt.in))
+			if err != nil || d.Hex() != tt.want {
+				t.Errorf("Hash() = %s, %v, want %s", d, err, tt.want)
+			}
+			if d, _ := HashString(tt.algo, tt.in); d.Hex() != tt.want {
+				t.Errorf("HashString() = %s, want %s", d, tt.want)
+			}
+			r := iotest.OneByteReader(strings.NewReader(tt.in))
+			if d, err := HashReader(tt.algo, r); err != nil || d.Hex() != tt.want {
+				t.Errorf("HashReader() = %s, %v, want %s", d, err, tt.want)
+			}
+			if h := tt.algo.New(); h.Size()*2 != len(tt.want) {
+				t.Errorf("Size() = %d, want %d", h.Size(), len(tt.want)/2)
+			}
+		})
+	}
+}
+
+// TestHashStreaming checks that every algorithm gives the same digest no
+// matter how the input is split, across block boundaries.
+func TestHashStreaming(t *testing.T) {
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	for algo := MD5; algo.Available(); algo++ {
+		for _, n := range []int{0, 1, 31, 32, 33, 63, 64, 65, 127, 128, 129, 256, 1000} {
+			want, _ := Hash(algo, data[:n])
+			h := algo.New()
+			for _, step := range []int{1, 3, 17, 64} {
+				h.Reset()
+				for p := data[:n]; len(p) > 0; {
+					c := min(step, len(p))
+					h.Write(p[:c])
+					p = p[c:]
+				}
+				if got := h.Sum(nil); !bytes.Equal(got, want) {
+					t.Errorf("%v: %d bytes in steps of %d = %x, want %x", algo, n, step, got, want)
+				}
+			}
+			// Sum must not change the running state.
+			h.Reset()
+			h.Write(data[:n/2])
+			h.Sum(nil)
+			h.Write(data[n/2 : n])
+			if got := h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("%v: Sum changed the state for %d bytes", algo, n)
+			}
+		}
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data")
+	if err := os.WriteFile(path, []byte("abc"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	d, err := HashFile(SHA256, path)
+	if err != nil || d.Hex() != "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Errorf("HashFile() = %s, %v", d, err)
+	}
+	if _, err := HashFile(SHA256, path+".missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("HashFile(missing) error = %v", err)
+	}
+	if _, err := HashFile(HashAlgo(0), path); !errors.Is(err, ErrUnknownHash) {
+		t.Errorf("HashFile(unknown) error = %v", err)
+	}
+}
+
+func TestDigestEncodings(t *testing.T) {
+	d, _ := HashString(SHA1, "abc")
+	tests := map[string]string{
+		d.Hex():       "a9993e364706816aba3e25717850c26c9cd0d89d",
+		d.Base64():    "qZk+NkcGgWq6PiVxeFDCbJzQ2J0=",
+		d.Base64URL(): "qZk-NkcGgWq6PiVxeFDCbJzQ2J0",
+		d.Base32():    "VGMT4NSHA2AWVOR6EVYXQUGCNSONBWE5",
+		d.String():    "a9993e364706816aba3e25717850c26c9cd0d89d",
+	}
+	for got, want := range tests {
+		if got != want {
+			t.Errorf("encoding = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	key, data := []byte("Jefe"), []byte("what do ya want for nothing?")
+	tests := []struct {
+		algo HashAlgo
+		want string
+	}{
+		// RFC 2104 and RFC 4231 test case 2.
+		{MD5, "750c783e6ab0b503eaa86e310a5db738"},
+		{SHA256, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+	}
+	for _, tt := range tests {
+		mac, err := HMAC(tt.algo, key, data)
+		if err != nil || mac.Hex() != tt.want {
+			t.Errorf("HMAC(%v) = %s, %v, want %s", tt.algo, mac, err, tt.want)
+		}
+		want, _ := hex.DecodeString(tt.want)
+		if !VerifyHMAC(tt.algo, key, data, want) {
+			t.Errorf("VerifyHMAC(%v) = false, want true", tt.algo)
+		}
+		want[0] ^= 1
+		if VerifyHMAC(tt.algo, key, data, want) {
+			t.Errorf("VerifyHMAC(%v) accepted a modified MAC", tt.algo)
+		}
+	}
+	if _, err := HMAC(BLAKE2b256, key, data); err != nil {
+		t.Errorf("HMAC(BLAKE2b256) error = %v", err)
+	}
+	if _, err := HMAC(XXH64, key, data); !errors.Is(err, ErrHMACUnsupported) {
+		t.Errorf("HMAC(XXH64) error = %v, want ErrHMACUnsupported", err)
+	}
+	if _, err := HMAC(HashAlgo(99), key, data); !errors.Is(err, ErrUnknownHash) {
+		t.Errorf("HMAC(99) error = %v, want ErrUnknownHash", err)
+	}
+	if VerifyHMAC(FNV1a64, key, data, nil) {
+		t.Error("VerifyHMAC(FNV1a64) = true, want false")
+	}
+}
+
+func TestNonCryptoSums(t *testing.T) {
+	if got := FNV1a32Sum([]byte("a")); got != 0xe40c292c {
+		t.Errorf("FNV1a32Sum() = %x", got)
+	}
+	if got := FNV1a64Sum([]byte("a")); got != 0xaf63dc4c8601ec8c {
+		t.Errorf("FNV1a64Sum() = %x", got)
+	}
+	if got := XXH64Sum([]byte("abc")); got != 0x44bc2cf5ad770999 {
+		t.Errorf("XXH64Sum() = %x", got)
+	}
+	if NewXXH64(1).Sum64() == XXH64Sum(nil) {
+		t.Error("NewXXH64 ignores the seed")
+	}
+}
+
+func TestHashAlgoString(t *testing.T) {
+	if SHA3x256.String() != "SHA3-256" || HashAlgo(0).String() != "HashAlgo(0)" {
+		t.Errorf("String() = %q, %q", SHA3x256, HashAlgo(0))
+	}
+	if _, err := Hash(HashAlgo(-1), nil); !errors.Is(err, ErrUnknownHash) {
+		t.Errorf("Hash(-1) error = %v", err)
+	}
+}
+
+func BenchmarkHash(b *testing.B) {
+	data := bytes.Repeat([]byte("x"), 4096)
+	for _, algo := range []HashAlgo{SHA256, BLAKE2b256, BLAKE2s256, FNV1a64, XXH64} {
+		b.Run(algo.String(), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = Hash(algo, data)
+			}
+		})
+	}
+}
+
+// hashBoundaryVectors hold digests of the first n bytes of boundaryInput,
+// at and around the block sizes of BLAKE2s (64), BLAKE2b (128) and the
+// XXH64 stripe (32). xxhSeeded uses the seed 0x9e3779b97f4a7c15.
+var hashBoundaryVectors = []struct {
+	n                              int
+	b2b256, b2b384, b2b512, b2s256 string
+	xxh, xxhSeeded                 string
+}{
+	{0, "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8", "b32811423377f52d7862286ee1a72ee540524380fda1724a6f25d7978c6fd3244a6caf0498812673c5e05ef583825100", "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce", "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9", "ef46db3751d8e999", "c4349fc93c010000"},
+	{31, "9adf65b53153b1caec84cd717e00e01c2000d0569704ce38d065180adee5d964", "e440945bce0807dc2fe07018e086ed53751b9bc3c0052cd039cb48ee1a80a0fd72f38c90d9a7280c9082b9f52c8a039b", "29f8b8c78c80f2fcb4bdf7825ed90a70d625ff785d262677e250c04f3720c888d03f8045e4edf3f5285bd39d928a10a7d0a5df00b8484ac2868142a1e8bea351", "aba4ad9b480b9df3d08ca5e87b0c2440d4e4ea21224c2eb42cbae469d089b931", "c346d2b59b4d8ee1", "f3da6d05709c035d"},
+	{32, "cb2f5160fc1f7e05a55ef49d340b48da2e5a78099d53393351cd579dd42503d6", "7df0b7be6c29a965d6c3a8056cc72bf36dd8849eb73fc1f23a3aa1902b869e0c8ee99663887ea76893e239c9e45988f7", "5c52920a7263e39d57920ca0cb752ac6d79a04fef8a7a216a1ecb7115ce06d89fd7d735bd6f4272555dba22c2d1c96e6352322c62c5630fde0f4777a76c3de2c", "05825607d7fdf2d82ef4c3c8c2aea961ad98d60edff7d018983e21204c0d93d1", "cbf59c5116ff32b4", "a1c89217e9d50750"},
+	{33, "b7634fe13c7aca3914ee896e22cfabc9da5b4f13e72a2ccbecb6d44bbda95bcc", "8116547b404d20aa0459b97af4e75d44703ee8e41084f10080f68fc6fa76c38c1047b5e17ca388ded34b5a83c87043c0", "83b098f262251bf660064a9d3511ce7687a09e6dfbb878299c30e93dfb43a9314db9a600337db26ebeedaf2256a96dabe9b29e7573ad11c3523d874dde5be7ed", "a742f8b6af82d8a6ca2357c5f1cf91defbd066267d75c048b352366585025962", "0c535d1acafb8ead", "e6a3c00cd6e74075"},
+	{63, "29e41a64fbdd2fd27612228623c0702222bf367451e7324287f181cb3dcf7237", "16bb371b2bcca20f406442146ab47467ed37a24d1e51115c2ed5c10b31435bb9fb5cd4025156e428b5a57701ec5dbf3c", "d10bf9a15b1c9fc8d41f89bb140bf0be08d2f3666176d13baac4d381358ad074c9d4748c300520eb026daeaea7c5b158892fde4e8ec17dc998dcd507df26eb63", "e57cb79487dd57902432b250733813bd96a84efce59f650fac26e6696aefafc3", "e26aa9e2a95f8e4f", "26a0acd772de057e"},
+	{64, "10d8e6d534b00939843fe9dcc4dae48cdf008f6b8b2b82b156f5404d874887f5", "11c8e1a6ad99f75bd0b8df1530549c6bf2e72d64e6703535ad06512417b0f335dfe07e63ccb8c5cf99d76ee1f653f609", "2fc6e69fa26a89a5ed269092cb9b2a449a4409a7a44011eecad13d7c4b0456602d402fa5844f1a7a758136ce3d5d8d0e8b86921ffff4f692dd95bdc8e5ff0052", "56f34e8b96557e90c1f24b52d0c89d51086acf1b00f634cf1dde9233b8eaaa3e", "f7c67301db6713f0", "2589245e62a1969b"},
+	{65, "84c04ab082c8ae24206561f77397704b627892089a05887a2a1996472bcfe15d", "31466a2f0ff943f08d69924b1049181670949cecc738075f410a6ef41a8e25bd2a8df14829701637aba4c97199eff213", "fcbe8be7dcb49a32dbdf239459e26308b84dff1ea480df8d104eeff34b46fae98627b450c2267d48c0946a697c5b59531452ac0484f1c84e3a33d0c339bb2e28", "1b53ee94aaf34e4b159d48de352c7f0661d0a40edff95a0b1639b4090e974472", "c31eb63b2ae4465b", "b230fc5f39677b06"},
+	{127, "f2fe67ff342e21b8f45e8f2e0bcd1d9243245d50ee6c78042e9c491388791c72", "0c046dce7c3ed50a4be7eca79fdeb9d821ebe28f5d82acadac3d7449e6892789313679018034a2ce6b42f006c02f19ee", "b6292669ccd38d5f01caae96ba272c76a879a45743afa0725d83b9ebb26665b731f1848c52f11972b6644f554c064fa90780dbbbf3a89d4fc31f67df3e5857ef", "f18417b39d617ab1c18fdf91ebd0fc6d5516bb34cf39364037bce81fa04cecb1", "464d085810ce0199", "12eb9fa86a05956f"},
+	{128, "c3582f71ebb2be66fa5dd750f80baae97554f3b015663c8be377cfcb2488c1d1", "a2c2acf7ce4079c02b7f38e2ef33bff531a31a7c7effe712c5348b4d616c0cba9b152679317984ec632d0c70eb11eece", "2319e3789c47e2daa5fe807f61bec2a1a6537fa03f19ff32e87eecbfd64b7e0e8ccff439ac333b040f19b0c4ddd11a61e24ac1fe0f10a039806c5dcc0da3d115", "1fa877de67259d19863a2a34bcc6962a2b25fcbf5cbecd7ede8f1fa36688a796", "7a7fe14647b9ab92", "a505233797be6d6e"},
+	{129, "f7f3c46ba2564ff4c4c162da1f5b605f9f1c4aa6a20652a9f9a337c1a2f5b9c9", "a95db6e5ccd191793ad20179bfd63e8c7aedf0cc1084549f73127e3fccc738b405ac2a93d692e76214320089121073e5", "f59711d44a031d5f97a9413c065d1e614c417ede998590325f49bad2fd444d3e4418be19aec4e11449ac1a57207898bc57d76a1bcf3566292c20c683a5c4648f", "5bd169e67c82c2c2e98ef7008bdf261f2ddf30b1c00f9e7f275bb3e8a28dc9a2", "0ba25dfd6e891fcf", "f6332bc096536bc7"},
+	{1024, "d75edc54bb5acee4cc8610568e82872ce79758afd38c51dfe6ccfab01c2d191f", "4089bab1aa6e45379ef642073a53ea57844c6139a1eaacec351f9af7c22470849af32141e8d66e4592afdaad9eb1b95d", "8d1090909017add40e749df2d0ebac43273d6fc816bc4ffaf2a6dfabe4206dea13677d2002399e4a38e700d8083db4af8341ee9b3a5147110b6a963a3894e4e2", "eefe540b091c081f91a31b4db99926352f05cc012a7a1402268923dd00a278d7", "138e26c65048ce29", "933ef96debcccc9b"},
+}
+
+// boundaryInput returns n bytes of the repeating pattern 0, 1, ..., 250.
+func boundaryInput(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+func TestHashBlockBoundaries(t *testing.T) {
+	for _, tt := range hashBoundaryVectors {
+		data := boundaryInput(tt.n)
+		cases := []struct {
+			name string
+			new  func() hash.Hash
+			want string
+		}{
+			{"BLAKE2b-256", BLAKE2b256.New, tt.b2b256},
+			{"BLAKE2b-384", BLAKE2b384.New, tt.b2b384},
+			{"BLAKE2b-512", BLAKE2b512.New, tt.b2b512},
+			{"BLAKE2s-256", BLAKE2s256.New, tt.b2s256},
+			{"XXH64", XXH64.New, tt.xxh},
+			{"XXH64-seeded", func() hash.Hash { return NewXXH64(0x9e3779b97f4a7c15) }, tt.xxhSeeded},
+		}
+		for _, c := range cases {
+			// Write in one call, then split at and across block boundaries.
+			for _, split := range []int{tt.n, 0, 1, 31, 32, 33, 63, 64, 65, 127, 128, 129, tt.n / 2} {
+				if split > tt.n {
+					continue
+				}
+				h := c.new()
+				h.Write(data[:split])
+				h.Write(data[split:])
+				if got := hex.EncodeToString(h.Sum(nil)); got != c.want {
+					t.Errorf("%s(%d bytes, split at %d) = %s, want %s", c.name, tt.n, split, got, c.want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package convert
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appleboy/com/bytesconv"
+)
+
+// sizeUnits maps the lower case unit symbols accepted by ParseSize to their
+// multiples. A bare prefix letter is SI, like Kubernetes quantities.
+var sizeUnits = map[string]int64{
+	"": 1, "b": 1,
+	"k": 1e3, "kb": 1e3, "ki": 1 << 10, "kib": 1 << 10,
+	"m": 1e6, "mb": 1e6, "mi": 1 << 20, "mib": 1 << 20,
+	"g": 1e9, "gb": 1e9, "gi": 1 << 30, "gib": 1 << 30,
+	"t": 1e12, "tb": 1e12, "ti": 1 << 40, "tib": 1 << 40,
+	"p": 1e15, "pb": 1e15, "pi": 1 << 50, "pib": 1 << 50,
+	"e": 1e18, "eb": 1e18, "ei": 1 << 60, "eib": 1 << 60,
+}
+
+/*
+ParseSize parses a byte size such as "10MB", "1.5GiB" or "512" into a
+number of bytes. SI units (kB, MB, GB, TB, PB, EB) are powers of 1000 and
+IEC units (KiB, MiB, GiB, TiB, PiB, EiB) are powers of 1024. Units are
+case-insensitive, the trailing B is optional and a space may separate the
+number from the unit.
+
+Sizes are never negative. A fraction must come out to a whole number of
+bytes ("1.5KiB" is 1536, "1.5B" fails with ErrTruncated), and results
+beyond int64 fail with ErrOverflow.
+
+Note that file.FormatSize labels binary multiples with SI symbols ("1.5 KB"
+for 1536 bytes), so its output reads back as decimal. Use the IEC symbols
+when the exact binary value matters.
+*/
+func ParseSize(s string) (int64, error) {
+	n, err := parseSize(s)
+	if err != nil {
+		return 0, &ConversionError{Value: s, Target: reflect.TypeFor[int64](), Err: err}
+	}
+	return n, nil
+}
+
+func parseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	end := 0
+	for end < len(s) && (isDigit(s[end]) || s[end] == '.') {
+		end++
+	}
+	num, unit := s[:end], strings.ToLower(strings.TrimLeft(s[end:], " "))
+	if !isDecimal(num) {
+		return 0, ErrSyntax
+	}
+	mult, ok := sizeUnits[unit]
+	if !ok {
+		return 0, ErrSyntax
+	}
+	r, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return 0, ErrSyntax
+	}
+	r.Mul(r, new(big.Rat).SetInt64(mult))
+	if !r.IsInt() {
+		return 0, ErrTruncated
+	}
+	if !r.Num().IsInt64() {
+		return 0, ErrOverflow
+	}
+	return r.Num().Int64(), nil
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// isDecimal reports whether s is digits with at most one decimal point and
+// at least one digit.
+func isDecimal(s string) bool {
+	digits, points := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case isDigit(s[i]):
+			digits++
+		case s[i] == '.':
+			points++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && points <= 1
+}
+
+// durationUnits maps the units accepted by ParseDuration to their length.
+var durationUnits = map[string]uint64{
+	"ns": uint64(time.Nanosecond),
+	"us": uint64(time.Microsecond),
+	"µs": uint64(time.Microsecond), // U+00B5 micro sign
+	"μs": uint64(time.Microsecond), // U+03BC Greek letter mu
+	"ms": uint64(time.Millisecond),
+	"s":  uint64(time.Second),
+	"m":  uint64(time.Minute),
+	"h":  uint64(time.Hour),
+	"d":  uint64(24 * time.Hour),
+	"w":  uint64(7 * 24 * time.Hour),
+}
+
+/*
+ParseDuration parses a duration like time.ParseDuration, and also accepts
+days ("d", 24 hours) and weeks ("w", 7 days) and spaces between the
+components, so it reads FormatDuration's output back.
+
+Example:
+
+	d, _ := convert.ParseDuration("1w 2d 3h") // 219h0m0s
+	d, _ = convert.ParseDuration("1.5d")      // 36h0m0s
+*/
+func ParseDuration(s string) (time.Duration, error) {
+	d, err := parseDuration(s)
+	if err != nil {
+		return 0, &ConversionError{Value: s, Target: reflect.TypeFor[time.Duration](), Err: err}
+	}
+	return d, nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, ErrSyntax
+	}
+	var total uint64
+	for s != "" {
+		// Integer part.
+		i := 0
+		var v uint64
+		for ; i < len(s) && isDigit(s[i]); i++ {
+			if v > (math.MaxUint64-9)/10 {
+				return 0, ErrOverflow
+			}
+			v = v*10 + uint64(s[i]-'0')
+		}
+		digits := i
+		// Fraction, kept as f / scale.
+		var f uint64
+		scale := 1.0
+		if i < len(s) && s[i] == '.' {
+			i++
+			for ; i < len(s) && isDigit(s[i]); i++ {
+				digits++
+				// Digits beyond what a uint64 holds only affect precision.
+				if f <= (math.MaxUint64-9)/10 {
+					f = f*10 + uint64(s[i]-'0')
+					scale *= 10
+				}
+			}
+		}
+		if digits == 0 {
+			return 0, ErrSyntax
+		}
+		// Unit: everything up to the next digit, '.' or space.
+		j := i
+		for j < len(s) && !isDigit(s[j]) && s[j] != '.' && s[j] != ' ' {
+			j++
+		}
+		unit, ok := durationUnits[s[i:j]]
+		if !ok {
+			return 0, ErrSyntax
+		}
+		if v > math.MaxInt64/unit {
+			return 0, ErrOverflow
+		}
+		v *= unit
+		if f > 0 {
+			v += uint64(float64(f) * (float64(unit) / scale))
+		}
+		total += v
+		if total > math.MaxInt64 {
+			return 0, ErrOverflow
+		}
+		s = strings.TrimLeft(s[j:], " ")
+	}
+	if neg {
+		return -time.Duration(total), nil // #nosec G115 -- range validated above
+	}
+	return time.Duration(total), nil // #nosec G115 -- range validated above
+}
+
+// durationParts are the components FormatDuration writes, largest first.
+var durationParts = [...]struct {
+	unit time.Duration
+	name string
+}{
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+	{time.Millisecond, "ms"},
+	{time.Microsecond, "µs"},
+	{time.Nanosecond, "ns"},
+}
+
+/*
+FormatDuration formats d as space separated components, leaving out the
+zero ones: 2h3m becomes "2h 3m" and 36h becomes "1d 12h". A day is 24
+hours. Round or truncate d first to drop small units, for example
+d.Round(time.Second). ParseDuration reads the output back.
+*/
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	// Work on the magnitude as uint64 so math.MinInt64 does not overflow.
+	u := uint64(d) // #nosec G115 -- negated below when d is negative
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	first := true
+	for _, p := range durationParts {
+		unit := uint64(p.unit)
+		if n := u / unit; n > 0 {
+			if !first {
+				b.WriteByte(' ')
+			}
+			first = false
+			b.WriteString(strconv.FormatUint(n, 10))
+			b.WriteString(p.name)
+			u -= n * unit
+		}
+	}
+	return b.String()
+}
+
+/*
+FormatNumber formats an integer, float or numeric string with a comma
+between every three integer digits. decimals is the number of digits after
+the decimal point; -1 uses the fewest digits that represent a float
+exactly and no fraction for integers. Integers are formatted exactly, even
+beyond 2^53.
+
+Bools, other types, NaN and infinities fail with ErrUnsupportedType, and
+strings that are not numbers with ErrSyntax.
+
+Example:
+
+	s, _ := convert.FormatNumber(1234567, -1)    // "1,234,567"
+	s, _ = convert.FormatNumber(-1234.5678, 2)   // "-1,234.57"
+	s, _ = convert.FormatNumber("9876543.21", 1) // "9,876,543.2"
+*/
+func FormatNumber(value any, decimals int) (string, error) {
+	s, err := formatNumber(value, decimals, 0)
+	if err != nil {
+		return "", &ConversionError{Value: value, Target: reflect.TypeFor[string](), Err: err}
+	}
+	return s, nil
+}
+
+/*
+FormatPercent formats a ratio as a percentage with thousand separators:
+0.256 becomes "25.6%" and 12 becomes "1,200%". decimals and errors are as
+for FormatNumber. The ratio is scaled by moving the decimal point, so 0.07
+gives "7%" rather than "7.000000000000001%".
+*/
+func FormatPercent(value any, decimals int) (string, error) {
+	s, err := formatNumber(value, decimals, 2)
+	if err != nil {
+		return "", &ConversionError{Value: value, Target: reflect.TypeFor[string](), Err: err}
+	}
+	return s + "%", nil
+}
+
+// formatNumber formats value times 10^shift with thousand separators.
+func formatNumber(value any, decimals, shift int) (string, error) {
+	digits, isInt, err := decimalText(value)
+	if err != nil {
+		return "", err
+	}
+
+	if shift > 0 {
+		digits = shiftPoint(digits, shift)
+	}
+	if !isInt && decimals >= 0 {
+		// Round through the exact decimal text, not the scaled float.
+		f, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			return "", ErrOverflow
+		}
+		digits = strconv.FormatFloat(f, 'f', decimals, 64)
+	}
+	if isInt && decimals > 0 {
+		digits += "." + strings.Repeat("0", decimals)
+	}
+	return groupThousands(digits), nil
+}
+
+// decimalText returns an integer, float or numeric string as plain
+// decimal text, reporting whether it is an integer. Integers are exact;
+// floats and non-integer strings use the shortest text that round-trips the
+// float64.
+func decimalText(value any) (digits string, isInt bool, err error) {
+	sc, ok := toScalar(value)
+	if !ok {
+		return "", false, ErrUnsupportedType
+	}
+	switch sc.kind {
+	case kindInt:
+		return strconv.FormatInt(sc.i, 10), true, nil
+	case kindUint:
+		return strconv.FormatUint(sc.u, 10), true, nil
+	case kindFloat:
+		if math.IsNaN(sc.f) || math.IsInf(sc.f, 0) {
+			return "", false, ErrUnsupportedType
+		}
+		return strconv.FormatFloat(sc.f, 'f', -1, sc.bits), false, nil
+	case kindString:
+		str := bytesconv.StrToBytes(strings.TrimSpace(sc.s))
+		if i, err := bytesconv.ParseInt(str, 10, 64); err == nil {
+			return strconv.FormatInt(i, 10), true, nil
+		}
+		if u, err := bytesconv.ParseUint(str, 10, 64); err == nil {
+			return strconv.FormatUint(u, 10), true, nil
+		}
+		f, err := bytesconv.ParseFloat(str, 64)
+		if err != nil {
+			return "", false, numericError(err)
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", false, ErrUnsupportedType
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), false, nil
+	}
+	return "", false, ErrUnsupportedType
+}
+
+// shiftPoint multiplies the decimal number s by 10^n by moving its decimal
+// point.
+func shiftPoint(s string, n int) string {
+	sign := ""
+	if s != "" && s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	for ; n > 0; n-- {
+		if frac != "" {
+			intPart += frac[:1]
+			frac = frac[1:]
+		} else {
+			intPart += "0"
+		}
+	}
+	intPart = strings.TrimLeft(intPart, "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	if frac != "" {
+		return sign + intPart + "." + frac
+	}
+	return sign + intPart
+}
+
+// groupThousands inserts a comma between every three digits of the integer
+// part of the decimal number s.
+func groupThousands(s string) string {
+	sign := ""
+	if s != "" && s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	b.Grow(len(sign) + len(s) + len(intPart)/3)
+	b.WriteString(sign)
+	for i := 0; i < len(intPart); i++ {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte(intPart[i])
+	}
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+/*
+ParseNumber parses a decimal number that may use commas as thousand
+separators, such as "1,234,567.89" or "-42". Separators are checked
+strictly: every group after the first has exactly three digits, so
+"1,23" and "12,3456" fail with ErrSyntax. Exponents are not accepted.
+*/
+func ParseNumber(s string) (float64, error) {
+	f, err := parseNumber(s)
+	if err != nil {
+		return 0, &ConversionError{Value: s, Target: reflect.TypeFor[float64](), Err: err}
+	}
+	return f, nil
+}
+
+func parseNumber(s string) (float64, error) {
+	str := strings.TrimSpace(s)
+	var b strings.Builder
+	b.Grow(len(str))
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		b.WriteByte(str[0])
+		str = str[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(str, ".")
+	if intPart == "" || (hasFrac && frac == "") {
+		return 0, ErrSyntax
+	}
+	if strings.Contains(intPart, ",") {
+		groups := strings.Split(intPart, ",")
+		for i, g := range groups {
+			if (i == 0 && (g == "" || len(g) > 3)) || (i > 0 && len(g) != 3) {
+				return 0, ErrSyntax
+			}
+		}
+		intPart = strings.Join(groups, "")
+	}
+	if !allDigits(intPart) || !allDigits(frac) {
+		return 0, ErrSyntax
+	}
+	b.WriteString(intPart)
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	f, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, ErrOverflow
+	}
+	return f, nil
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+package convert
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		err  error
+	}{
+		{"0", 0, nil},
+		{"512", 512, nil},
+		{"512B", 512, nil},
+		{"10MB", 10_000_000, nil},
+		{"10mb", 10_000_000, nil},
+		{"10 MB", 10_000_000, nil},
+		{"10M", 10_000_000, nil},
+		{"1kB", 1000, nil},
+		{"1KiB", 1024, nil},
+		{"1.5GiB", 1536 << 20, nil},
+		{"1.5 KiB", 1536, nil},
+		{"2Ti", 2 << 40, nil},
+		{" 3 pb ", 3e15, nil},
+		{"7EiB", 7 << 60, nil},
+		{"9.2EB", 9_200_000_000_000_000_000, nil},
+		{".5kB", 500, nil},
+		{"1.5B", 0, ErrTruncated},
+		{"1.0001KiB", 0, ErrTruncated},
+		{"8EiB", 0, ErrOverflow},
+		{"10EB", 0, ErrOverflow},
+		{"", 0, ErrSyntax},
+		{"MB", 0, ErrSyntax},
+		{"-1MB", 0, ErrSyntax},
+		{"1.2.3MB", 0, ErrSyntax},
+		{"10XB", 0, ErrSyntax},
+		{"10 M B", 0, ErrSyntax},
+		{"1e3", 0, ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSize(tt.in)
+			if tt.err != nil {
+				var ce *ConversionError
+				if !errors.Is(err, tt.err) || !errors.As(err, &ce) {
+					t.Fatalf("ParseSize(%q) error = %v, want %v", tt.in, err, tt.err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseSize(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		err  error
+	}{
+		{"0", 0, nil},
+		{"-0", 0, nil},
+		{"1h", time.Hour, nil},
+		{"1h30m", 90 * time.Minute, nil},
+		{"2h 3m", 2*time.Hour + 3*time.Minute, nil},
+		{"1d", 24 * time.Hour, nil},
+		{"1.5d", 36 * time.Hour, nil},
+		{"1w 2d 3h", 219 * time.Hour, nil},
+		{"-1w", -7 * 24 * time.Hour, nil},
+		{"+2s", 2 * time.Second, nil},
+		{"1.5s", 1500 * time.Millisecond, nil},
+		{".5m", 30 * time.Second, nil},
+		{"1ms 500µs", 1500 * time.Microsecond, nil},
+		{"3μs", 3 * time.Microsecond, nil},
+		{"4us", 4 * time.Microsecond, nil},
+		{"100ns", 100, nil},
+		{"2562047h47m16.854775807s", math.MaxInt64, nil},
+		{"15251w", 0, ErrOverflow},
+		{"2562047h47m16.854775808s", 0, ErrOverflow},
+		{"99999999999999999999999ns", 0, ErrOverflow},
+		{"", 0, ErrSyntax},
+		{"-", 0, ErrSyntax},
+		{"1", 0, ErrSyntax},
+		{"1y", 0, ErrSyntax},
+		{"d", 0, ErrSyntax},
+		{".d", 0, ErrSyntax},
+		{"1h-1m", 0, ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDuration(tt.in)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("ParseDuration(%q) error = %v, want %v", tt.in, err, tt.err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDurationMatchesTime(t *testing.T) {
+	for _, s := range []string{"300ms", "-1.5h", "2h45m", "1h2m3s4ms5us6ns", "0.000000001s", "1.0000000001s"} {
+		want, err := time.ParseDuration(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParseDuration(s)
+		if err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{2*time.Hour + 3*time.Minute, "2h 3m"},
+		{36 * time.Hour, "1d 12h"},
+		{90 * time.Second, "1m 30s"},
+		{1500 * time.Microsecond, "1ms 500µs"},
+		{-5 * time.Second, "-5s"},
+		{1, "1ns"},
+		{math.MinInt64, "-106751d 23h 47m 16s 854ms 775µs 808ns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := FormatDuration(tt.in)
+			if got != tt.want {
+				t.Fatalf("FormatDuration(%d) = %q, want %q", tt.in, got, tt.want)
+			}
+			if tt.in == math.MinInt64 {
+				return
+			}
+			back, err := ParseDuration(got)
+			if err != nil || back != tt.in {
+				t.Errorf("ParseDuration(%q) = %v, %v, want %v", got, back, err, tt.in)
+			}
+		})
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		in       any
+		decimals int
+		want     string
+	}{
+		{0, -1, "0"},
+		{999, -1, "999"},
+		{1000, -1, "1,000"},
+		{1234567, -1, "1,234,567"},
+		{-1234567, -1, "-1,234,567"},
+		{int64(math.MinInt64), -1, "-9,223,372,036,854,775,808"},
+		{uint64(math.MaxUint64), -1, "18,446,744,073,709,551,615"},
+		{1234, 2, "1,234.00"},
+		{1234.5, -1, "1,234.5"},
+		{-1234.5678, 2, "-1,234.57"},
+		{0.1, -1, "0.1"},
+		{float32(0.1), -1, "0.1"},
+		{1e21, -1, "1,000,000,000,000,000,000,000"},
+		{"9876543.21", 1, "9,876,543.2"},
+		{"+1234", -1, "1,234"},
+		{"007", -1, "7"},
+		{"18446744073709551615", -1, "18,446,744,073,709,551,615"},
+		{ToPtr(12345), 0, "12,345"},
+		{time.Duration(1500), -1, "1,500"},
+	}
+	for _, tt := range tests {
+		got, err := FormatNumber(tt.in, tt.decimals)
+		if err != nil || got != tt.want {
+			t.Errorf("FormatNumber(%v, %d) = %q, %v, want %q", tt.in, tt.decimals, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		in  any
+		err error
+	}{
+		{true, ErrUnsupportedType},
+		{struct{}{}, ErrUnsupportedType},
+		{nil, ErrUnsupportedType},
+		{math.NaN(), ErrUnsupportedType},
+		{math.Inf(1), ErrUnsupportedType},
+		{"abc", ErrSyntax},
+		{"1,000", ErrSyntax},
+	} {
+		if _, err := FormatNumber(tt.in, -1); !errors.Is(err, tt.err) {
+			t.Errorf("FormatNumber(%v) error = %v, want %v", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		in       any
+		decimals int
+		want     string
+	}{
+		{0.256, -1, "25.6%"},
+		{0.07, -1, "7%"},
+		{0.07, 2, "7.00%"},
+		{0.12345, 1, "12.3%"},
+		{1, -1, "100%"},
+		{12, -1, "1,200%"},
+		{-0.5, -1, "-50%"},
+		{0.0001, -1, "0.01%"},
+		{"0.333", 0, "33%"},
+	}
+	for _, tt := range tests {
+		got, err := FormatPercent(tt.in, tt.decimals)
+		if err != nil || got != tt.want {
+			t.Errorf("FormatPercent(%v, %d) = %q, %v, want %q", tt.in, tt.decimals, got, err, tt.want)
+		}
+	}
+	if _, err := FormatPercent("x", -1); !errors.Is(err, ErrSyntax) {
+		t.Errorf("FormatPercent(x) error = %v, want ErrSyntax", err)
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		err  error
+	}{
+		{"0", 0, nil},
+		{"42", 42, nil},
+		{"-42", -42, nil},
+		{"+1,000", 1000, nil},
+		{"1,234,567.89", 1234567.89, nil},
+		{"12,345", 12345, nil},
+		{" 1234.5 ", 1234.5, nil},
+		{"", 0, ErrSyntax},
+		{"1,23", 0, ErrSyntax},
+		{"12,3456", 0, ErrSyntax},
+		{",123", 0, ErrSyntax},
+		{"1234,567", 0, ErrSyntax},
+		{"1.", 0, ErrSyntax},
+		{".5", 0, ErrSyntax},
+		{"1e3", 0, ErrSyntax},
+		{"1.2.3", 0, ErrSyntax},
+		{"1,000.5,0", 0, ErrSyntax},
+		{"NaN", 0, ErrSyntax},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.in)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("ParseNumber(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseNumber(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	// FormatNumber output reads back.
+	s, _ := FormatNumber(-9876543.125, -1)
+	if got, err := ParseNumber(s); err != nil || got != -9876543.125 {
+		t.Errorf("ParseNumber(%q) = %v, %v", s, got, err)
+	}
+}
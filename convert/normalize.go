@@ -0,0 +1,92 @@
+package convert
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// NFC returns s in Unicode Normalization Form C, with characters composed:
+// "é" becomes "é". Use it before comparing or storing user input.
+func NFC(s string) string { return norm.NFC.String(s) }
+
+// NFD returns s in Unicode Normalization Form D, with characters
+// decomposed into a base character and combining marks.
+func NFD(s string) string { return norm.NFD.String(s) }
+
+// NFKC returns s in Unicode Normalization Form KC. Besides composing, it
+// replaces compatibility characters with their plain equivalents, such as
+// "ﬁ" with "fi", "²" with "2" and full-width "Ａ" with "A".
+func NFKC(s string) string { return norm.NFKC.String(s) }
+
+// NFKD returns s in Unicode Normalization Form KD, the decomposed form of
+// NFKC.
+func NFKD(s string) string { return norm.NFKD.String(s) }
+
+// FoldWidth maps full-width ASCII letters, digits and punctuation and the
+// ideographic space to ASCII, and half-width Katakana and Hangul to their
+// normal full-width forms. It is the usual cleanup for text that came from
+// Big5 or Shift_JIS sources, where full-width Latin text is common.
+func FoldWidth(s string) string { return width.Fold.String(s) }
+
+// ToHalfWidth maps every character that has a half-width or narrow form to
+// it, including Katakana, which becomes half-width Katakana.
+func ToHalfWidth(s string) string { return width.Narrow.String(s) }
+
+// ToFullWidth maps every character that has a full-width or wide form to
+// it, such as ASCII "A1" to "Ａ１".
+func ToFullWidth(s string) string { return width.Widen.String(s) }
+
+// strokeLetters are Latin letters whose diacritic is part of the letter and
+// so is not removed by decomposition.
+var strokeLetters = map[rune]rune{
+	'Đ': 'D', 'đ': 'd', 'Ħ': 'H', 'ħ': 'h', 'Ł': 'L', 'ł': 'l',
+	'Ø': 'O', 'ø': 'o', 'Ŧ': 'T', 'ŧ': 't', 'ı': 'i',
+}
+
+/*
+RemoveDiacritics strips accents and other diacritics from Latin and Greek
+letters: the text is decomposed with NFD, the combining marks that follow a
+Latin or Greek letter are dropped and the result is recomposed with NFC.
+Letters with a stroke, such as "ø" and "ł", are replaced with the plain
+letter. Marks in other scripts, such as Devanagari vowel signs or the
+Cyrillic "й", are kept since they are part of the spelling.
+
+Example:
+
+	convert.RemoveDiacritics("Crème Brûlée à Łódź") // "Creme Brulee a Lodz"
+*/
+func RemoveDiacritics(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	strip := false
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			if !strip {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		strip = unicode.In(r, unicode.Latin, unicode.Greek)
+		if plain, ok := strokeLetters[r]; ok {
+			r = plain
+		}
+		b.WriteRune(r)
+	}
+	return norm.NFC.String(b.String())
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package convert
+
+import "testing"
+
+func TestNormalizationForms(t *testing.T) {
+	const composed, decomposed = "café", "café"
+	if got := NFC(decomposed); got != composed {
+		t.Errorf("NFC(%q) = %q, want %q", decomposed, got, composed)
+	}
+	if got := NFD(composed); got != decomposed {
+		t.Errorf("NFD(%q) = %q, want %q", composed, got, decomposed)
+	}
+	if got := NFKC("ﬁ²Ａ"); got != "fi2A" {
+		t.Errorf("NFKC() = %q, want %q", got, "fi2A")
+	}
+	if got := NFKD("ﬁé"); got != "fié" {
+		t.Errorf("NFKD() = %q, want %q", got, "fié")
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(string) string
+		in   string
+		want string
+	}{
+		{"FoldWidth full-width ASCII", FoldWidth, "Ｈｅｌｌｏ，１２３", "Hello,123"},
+		{"FoldWidth ideographic space", FoldWidth, "中文　測試", "中文 測試"},
+		{"FoldWidth half-width Katakana", FoldWidth, "ｱｲｳ", "アイウ"},
+		{"FoldWidth keeps CJK", FoldWidth, "繁體字", "繁體字"},
+		{"ToHalfWidth", ToHalfWidth, "アイウＡ１", "ｱｲｳA1"},
+		{"ToFullWidth", ToFullWidth, "A1 ｱ", "Ａ１　ア"},
+	}
+	for _, tt := range tests {
+		if got := tt.fn(tt.in); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRemoveDiacritics(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"plain ASCII", "plain ASCII"},
+		{"Crème Brûlée à Łódź", "Creme Brulee a Lodz"},
+		{"Ångström Øresund", "Angstrom Oresund"},
+		{"Tiếng Việt Đà Nẵng", "Tieng Viet Da Nang"},
+		{"café", "cafe"},
+		{"Αθήνα", "Αθηνα"},
+		// Marks outside Latin and Greek are part of the spelling.
+		{"йод", "йод"},
+		{"नमस्ते", "नमस्ते"},
+		{"中文", "中文"},
+	}
+	for _, tt := range tests {
+		if got := RemoveDiacritics(tt.in); got != tt.want {
+			t.Errorf("RemoveDiacritics(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,500 @@
+package convert
+
+import (
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	smallWords = [...]string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen",
+		"seventeen", "eighteen", "nineteen",
+	}
+	tensWords = [...]string{
+		2: "twenty", 3: "thirty", 4: "forty", 5: "fifty",
+		6: "sixty", 7: "seventy", 8: "eighty", 9: "ninety",
+	}
+	scaleWords = [...]string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+	}
+)
+
+// splitDecimal splits decimal text into its sign, integer part as a uint64
+// and fraction digits.
+func splitDecimal(digits string) (neg bool, n uint64, frac string, err error) {
+	if digits != "" && digits[0] == '-' {
+		neg, digits = true, digits[1:]
+	}
+	intPart, frac, _ := strings.Cut(digits, ".")
+	n, err = strconv.ParseUint(intPart, 10, 64)
+	if err != nil {
+		return false, 0, "", ErrOverflow
+	}
+	return neg, n, strings.TrimRight(frac, "0"), nil
+}
+
+/*
+NumberToWords spells out an integer, float or numeric string in English,
+the way amounts are written on cheques and invoices. The integer part may
+be anything up to the uint64 range; a fraction is read digit by digit after
+"point".
+
+Bools, other types, NaN and infinities fail with ErrUnsupportedType,
+strings that are not numbers with ErrSyntax, and integer parts beyond
+uint64 with ErrOverflow.
+
+Example:
+
+	s, _ := convert.NumberToWords(1234)  // "one thousand two hundred thirty-four"
+	s, _ = convert.NumberToWords(-12.05) // "negative twelve point zero five"
+	s, _ = convert.NumberToWords("1e6")  // "one million"
+*/
+func NumberToWords(value any) (string, error) {
+	s, err := numberToWords(value)
+	if err != nil {
+		return "", &ConversionError{Value: value, Target: reflect.TypeFor[string](), Err: err}
+	}
+	return s, nil
+}
+
+func numberToWords(value any) (string, error) {
+	digits, _, err := decimalText(value)
+	if err != nil {
+		return "", err
+	}
+	neg, n, frac, err := splitDecimal(digits)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if neg && (n != 0 || frac != "") {
+		b.WriteString("negative ")
+	}
+	writeEnglishInt(&b, n)
+	if frac != "" {
+		b.WriteString(" point")
+		for i := 0; i < len(frac); i++ {
+			b.WriteByte(' ')
+			b.WriteString(smallWords[frac[i]-'0'])
+		}
+	}
+	return b.String(), nil
+}
+
+func writeEnglishInt(b *strings.Builder, n uint64) {
+	if n == 0 {
+		b.WriteString(smallWords[0])
+		return
+	}
+	var groups [len(scaleWords)]uint64
+	for i := 0; n > 0; i++ {
+		groups[i] = n % 1000
+		n /= 1000
+	}
+	first := true
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		if g >= 100 {
+			b.WriteString(smallWords[g/100])
+			b.WriteString(" hundred")
+			if g %= 100; g > 0 {
+				b.WriteByte(' ')
+			}
+		}
+		switch {
+		case g >= 20:
+			b.WriteString(tensWords[g/10])
+			if g%10 > 0 {
+				b.WriteByte('-')
+				b.WriteString(smallWords[g%10])
+			}
+		case g > 0:
+			b.WriteString(smallWords[g])
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+			b.WriteString(scaleWords[i])
+		}
+	}
+}
+
+// ChineseNumeralStyle selects the characters FormatChineseNumeral writes.
+type ChineseNumeralStyle int
+
+// Chinese numeral styles. The financial forms (大寫) are used on cheques,
+// invoices and contracts because they cannot be altered by adding strokes.
+const (
+	// ChineseRegular writes 一百二十三萬 in Traditional characters.
+	ChineseRegular ChineseNumeralStyle = iota
+	// ChineseRegularSimplified writes 一百二十三万.
+	ChineseRegularSimplified
+	// ChineseFinancial writes 壹佰貳拾參萬.
+	ChineseFinancial
+	// ChineseFinancialSimplified writes 壹佰贰拾叁万.
+	ChineseFinancialSimplified
+)
+
+type chineseNumerals struct {
+	digits    [10]string
+	small     [3]string // 十, 百, 千
+	big       [5]string // "", 萬, 億, 兆, 京
+	neg, dot  string
+	financial bool
+}
+
+var chineseStyles = [...]chineseNumerals{
+	ChineseRegular: {
+		digits: [10]string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"},
+		small:  [3]string{"十", "百", "千"},
+		big:    [5]string{"", "萬", "億", "兆", "京"},
+		neg:    "負",
+		dot:    "點",
+	},
+	ChineseRegularSimplified: {
+		digits: [10]string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"},
+		small:  [3]string{"十", "百", "千"},
+		big:    [5]string{"", "万", "亿", "兆", "京"},
+		neg:    "负",
+		dot:    "点",
+	},
+	ChineseFinancial: {
+		digits:    [10]string{"零", "壹", "貳", "參", "肆", "伍", "陸", "柒", "捌", "玖"},
+		small:     [3]string{"拾", "佰", "仟"},
+		big:       [5]string{"", "萬", "億", "兆", "京"},
+		neg:       "負",
+		dot:       "點",
+		financial: true,
+	},
+	ChineseFinancialSimplified: {
+		digits:    [10]string{"零", "壹", "贰", "叁", "肆", "伍", "陆", "柒", "捌", "玖"},
+		small:     [3]string{"拾", "佰", "仟"},
+		big:       [5]string{"", "万", "亿", "兆", "京"},
+		neg:       "负",
+		dot:       "点",
+		financial: true,
+	},
+}
+
+/*
+FormatChineseNumeral writes an integer, float or numeric string as a
+Chinese numeral in the given style. The integer part uses the units 萬, 億,
+兆 and 京 (each 10^4 times the previous) and may be anything up to the
+uint64 range; a fraction is read digit by digit after 點. Inner zeros are
+written as a single 零, and the regular styles drop the leading 一 of 一十,
+so 15 is 十五.
+
+Errors are as for NumberToWords; an unknown style fails with
+ErrUnsupportedType.
+
+Example:
+
+	s, _ := convert.FormatChineseNumeral(123, convert.ChineseRegular)    // "一百二十三"
+	s, _ = convert.FormatChineseNumeral(123, convert.ChineseFinancial)   // "壹佰貳拾參"
+	s, _ = convert.FormatChineseNumeral(100050, convert.ChineseRegular)  // "十萬零五十"
+	s, _ = convert.FormatChineseNumeral("-3.14", convert.ChineseRegular) // "負三點一四"
+*/
+func FormatChineseNumeral(value any, style ChineseNumeralStyle) (string, error) {
+	s, err := formatChineseNumeral(value, style)
+	if err != nil {
+		return "", &ConversionError{Value: value, Target: reflect.TypeFor[string](), Err: err}
+	}
+	return s, nil
+}
+
+func formatChineseNumeral(value any, style ChineseNumeralStyle) (string, error) {
+	if style < 0 || int(style) >= len(chineseStyles) {
+		return "", ErrUnsupportedType
+	}
+	cs := &chineseStyles[style]
+	digits, _, err := decimalText(value)
+	if err != nil {
+		return "", err
+	}
+	neg, n, frac, err := splitDecimal(digits)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if neg && (n != 0 || frac != "") {
+		b.WriteString(cs.neg)
+	}
+	cs.writeInt(&b, n)
+	if frac != "" {
+		b.WriteString(cs.dot)
+		for i := 0; i < len(frac); i++ {
+			b.WriteString(cs.digits[frac[i]-'0'])
+		}
+	}
+	return b.String(), nil
+}
+
+func (cs *chineseNumerals) writeInt(b *strings.Builder, n uint64) {
+	if n == 0 {
+		b.WriteString(cs.digits[0])
+		return
+	}
+	var sections [len(cs.big)]uint64
+	top := 0
+	for i := 0; n > 0; i++ {
+		sections[i] = n % 10000
+		n /= 10000
+		top = i
+	}
+	written, zero := false, false
+	for i := top; i >= 0; i-- {
+		sec := sections[i]
+		if sec == 0 {
+			zero = written
+			continue
+		}
+		if written && sec < 1000 {
+			zero = true
+		}
+		if zero {
+			b.WriteString(cs.digits[0])
+			zero = false
+		}
+		cs.writeSection(b, sec, i == top)
+		b.WriteString(cs.big[i])
+		written = true
+	}
+}
+
+// writeSection writes a value below 10000. leading reports whether it is
+// the first section of the number, where 一十 is shortened to 十.
+func (cs *chineseNumerals) writeSection(b *strings.Builder, sec uint64, leading bool) {
+	units := [4]uint64{1000, 100, 10, 1}
+	written, zero := false, false
+	for i, unit := range units {
+		d := sec / unit % 10
+		if d == 0 {
+			zero = written
+			continue
+		}
+		if zero {
+			b.WriteString(cs.digits[0])
+			zero = false
+		}
+		if !(d == 1 && unit == 10 && leading && !written && !cs.financial) {
+			b.WriteString(cs.digits[d])
+		}
+		if unit > 1 {
+			b.WriteString(cs.small[2-i])
+		}
+		written = true
+		if sec%unit == 0 {
+			break
+		}
+	}
+}
+
+// chineseDigitValues maps every digit character ParseChineseNumeral
+// accepts, in any style, to its value.
+var chineseDigitValues = map[rune]uint64{
+	'零': 0, '〇': 0,
+	'一': 1, '壹': 1, '弌': 1,
+	'二': 2, '貳': 2, '贰': 2, '兩': 2, '两': 2,
+	'三': 3, '參': 3, '叁': 3, '参': 3,
+	'四': 4, '肆': 4,
+	'五': 5, '伍': 5,
+	'六': 6, '陸': 6, '陆': 6,
+	'七': 7, '柒': 7,
+	'八': 8, '捌': 8,
+	'九': 9, '玖': 9,
+}
+
+// chineseUnitValues maps the unit characters to their value.
+var chineseUnitValues = map[rune]uint64{
+	'十': 10, '拾': 10,
+	'百': 100, '佰': 100,
+	'千': 1000, '仟': 1000,
+	'萬': 1e4, '万': 1e4,
+	'億': 1e8, '亿': 1e8,
+	'兆': 1e12,
+	'京': 1e16,
+}
+
+/*
+ParseChineseNumeral parses a Chinese numeral in any of the styles
+FormatChineseNumeral writes, Traditional or Simplified, into an int64. It
+also accepts:
+  - a leading 負, 负 or '-'
+  - 兩 and 两 for 2, and 〇 for 零
+  - ASCII digits in place of a digit character, as in "3億2000萬"
+  - digit by digit numerals without units, as in 二〇二四
+  - the colloquial shortening of a trailing unit, so 一萬五 is 15000 and
+    兩百五 is 250
+
+A fraction (點) or malformed numeral fails with ErrSyntax and a value
+outside int64 with ErrOverflow, wrapped in a *ConversionError.
+
+Example:
+
+	n, _ := convert.ParseChineseNumeral("一百二十三") // 123
+	n, _ = convert.ParseChineseNumeral("壹佰貳拾參萬") // 1230000
+	n, _ = convert.ParseChineseNumeral("負十萬零五十") // -100050
+*/
+func ParseChineseNumeral(s string) (int64, error) {
+	n, err := parseChineseNumeral(s)
+	if err != nil {
+		return 0, &ConversionError{Value: s, Target: reflect.TypeFor[int64](), Err: err}
+	}
+	return n, nil
+}
+
+func parseChineseNumeral(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	for _, sign := range []string{"負", "负", "-"} {
+		if rest, ok := strings.CutPrefix(s, sign); ok {
+			neg, s = true, rest
+			break
+		}
+	}
+	if s == "" {
+		return 0, ErrSyntax
+	}
+	u, err := parseChineseUnsigned(s)
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, ErrOverflow
+		}
+		return -int64(u), nil
+	}
+	if u > math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return int64(u), nil
+}
+
+func parseChineseUnsigned(s string) (uint64, error) {
+	hasUnit := strings.ContainsFunc(s, func(r rune) bool { _, ok := chineseUnitValues[r]; return ok })
+	if !hasUnit {
+		// Digit by digit, as in 二〇二四.
+		var n uint64
+		for _, r := range s {
+			d, ok := chineseDigitValues[r]
+			if !ok && '0' <= r && r <= '9' {
+				d, ok = uint64(r-'0'), true
+			}
+			if !ok {
+				return 0, ErrSyntax
+			}
+			hi, lo := bits.Mul64(n, 10)
+			if hi != 0 || lo+d < lo {
+				return 0, ErrOverflow
+			}
+			n = lo + d
+		}
+		return n, nil
+	}
+
+	var (
+		total, section uint64
+		num            uint64 // digit or ASCII number waiting for its unit
+		hasNum         bool
+		lastUnit       uint64 // most recent unit, for 一萬五
+		lastBig        uint64 = math.MaxUint64
+		lastSmall      uint64 = 10000
+		zeroSince      bool   // a 零 appeared since lastUnit
+	)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if '0' <= r && r <= '9' {
+			if hasNum {
+				return 0, ErrSyntax
+			}
+			j := i
+			for j < len(s) && '0' <= s[j] && s[j] <= '9' {
+				j++
+			}
+			v, err := strconv.ParseUint(s[i:j], 10, 64)
+			if err != nil {
+				return 0, ErrOverflow
+			}
+			num, hasNum = v, true
+			i = j
+			continue
+		}
+		i += size
+		if d, ok := chineseDigitValues[r]; ok {
+			if d == 0 {
+				if hasNum {
+					return 0, ErrSyntax
+				}
+				zeroSince = true
+				continue
+			}
+			if hasNum {
+				return 0, ErrSyntax
+			}
+			num, hasNum = d, true
+			continue
+		}
+		unit, ok := chineseUnitValues[r]
+		if !ok {
+			return 0, ErrSyntax
+		}
+		lastUnit, zeroSince = unit, false
+		if unit < 1e4 {
+			if unit >= lastSmall {
+				return 0, ErrSyntax
+			}
+			lastSmall = unit
+			if !hasNum {
+				if unit != 10 {
+					return 0, ErrSyntax
+				}
+				num = 1 // 十五
+			}
+			if num >= 10 {
+				return 0, ErrSyntax
+			}
+			section += num * unit
+			num, hasNum = 0, false
+			continue
+		}
+		if unit >= lastBig {
+			return 0, ErrSyntax
+		}
+		lastBig, lastSmall = unit, 10000
+		section += num
+		num, hasNum = 0, false
+		if section == 0 || section >= 1e4 {
+			return 0, ErrSyntax
+		}
+		hi, lo := bits.Mul64(section, unit)
+		if hi != 0 || total+lo < total {
+			return 0, ErrOverflow
+		}
+		total += lo
+		section = 0
+	}
+	if hasNum && !zeroSince && lastUnit >= 100 {
+		// 一萬五 is 15000 and 兩百五 is 250.
+		if num >= 10 {
+			return 0, ErrSyntax
+		}
+		num *= lastUnit / 10
+	}
+	section += num
+	if total+section < total {
+		return 0, ErrOverflow
+	}
+	return total + section, nil
+}
@@ -0,0 +1,181 @@
+package convert
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNumberToWords(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{0, "zero"},
+		{7, "seven"},
+		{13, "thirteen"},
+		{20, "twenty"},
+		{42, "forty-two"},
+		{100, "one hundred"},
+		{101, "one hundred one"},
+		{999, "nine hundred ninety-nine"},
+		{1000, "one thousand"},
+		{1234, "one thousand two hundred thirty-four"},
+		{100050, "one hundred thousand fifty"},
+		{1000000, "one million"},
+		{-15, "negative fifteen"},
+		{int64(math.MaxInt64), "nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred seven"},
+		{int64(math.MinInt64), "negative nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight"},
+		{uint64(math.MaxUint64), "eighteen quintillion four hundred forty-six quadrillion seven hundred forty-four trillion seventy-three billion seven hundred nine million five hundred fifty-one thousand six hundred fifteen"},
+		{12.05, "twelve point zero five"},
+		{-0.5, "negative zero point five"},
+		{"1234.50", "one thousand two hundred thirty-four point five"},
+		{"1e6", "one million"},
+		{ToPtr(3), "three"},
+	}
+	for _, tt := range tests {
+		got, err := NumberToWords(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("NumberToWords(%v) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		in  any
+		err error
+	}{
+		{true, ErrUnsupportedType},
+		{nil, ErrUnsupportedType},
+		{math.NaN(), ErrUnsupportedType},
+		{"abc", ErrSyntax},
+		{1e21, ErrOverflow},
+	} {
+		if _, err := NumberToWords(tt.in); !errors.Is(err, tt.err) {
+			t.Errorf("NumberToWords(%v) error = %v, want %v", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestFormatChineseNumeral(t *testing.T) {
+	tests := []struct {
+		in    any
+		style ChineseNumeralStyle
+		want  string
+	}{
+		{0, ChineseRegular, "零"},
+		{10, ChineseRegular, "十"},
+		{15, ChineseRegular, "十五"},
+		{110, ChineseRegular, "一百一十"},
+		{123, ChineseRegular, "一百二十三"},
+		{1001, ChineseRegular, "一千零一"},
+		{1010, ChineseRegular, "一千零一十"},
+		{10000, ChineseRegular, "一萬"},
+		{100000, ChineseRegular, "十萬"},
+		{100050, ChineseRegular, "十萬零五十"},
+		{120030405, ChineseRegular, "一億二千零三萬零四百零五"},
+		{100000001, ChineseRegular, "一億零一"},
+		{int64(math.MaxInt64), ChineseRegular, "九百二十二京三千三百七十二兆零三百六十八億五千四百七十七萬五千八百零七"},
+		{-3.14, ChineseRegular, "負三點一四"},
+		{"12.50", ChineseRegular, "十二點五"},
+		{123, ChineseFinancial, "壹佰貳拾參"},
+		{15, ChineseFinancial, "壹拾伍"},
+		{10203, ChineseFinancial, "壹萬零貳佰零參"},
+		{123, ChineseRegularSimplified, "一百二十三"},
+		{100000000, ChineseRegularSimplified, "一亿"},
+		{-12345, ChineseFinancialSimplified, "负壹万贰仟叁佰肆拾伍"},
+		{0.5, ChineseFinancialSimplified, "零点伍"},
+	}
+	for _, tt := range tests {
+		got, err := FormatChineseNumeral(tt.in, tt.style)
+		if err != nil || got != tt.want {
+			t.Errorf("FormatChineseNumeral(%v, %d) = %q, %v, want %q", tt.in, tt.style, got, err, tt.want)
+		}
+	}
+
+	if _, err := FormatChineseNumeral(1, ChineseNumeralStyle(9)); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("unknown style error = %v, want ErrUnsupportedType", err)
+	}
+	if _, err := FormatChineseNumeral("abc", ChineseRegular); !errors.Is(err, ErrSyntax) {
+		t.Errorf("FormatChineseNumeral(abc) error = %v, want ErrSyntax", err)
+	}
+}
+
+func TestParseChineseNumeral(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"零", 0},
+		{"五", 5},
+		{"十", 10},
+		{"十五", 15},
+		{"二十", 20},
+		{"一百零五", 105},
+		{"一百二十三", 123},
+		{"一千零五十", 1050},
+		{"壹佰貳拾參萬", 1230000},
+		{"壹仟贰佰叁拾肆", 1234},
+		{"十萬零五十", 100050},
+		{"一億二千零三萬零四百零五", 120030405},
+		{"负一亿", -100000000},
+		{"負十萬零五十", -100050},
+		{"-三", -3},
+		{"兩千", 2000},
+		{"二〇二四", 2024},
+		{"3億2000萬", 320000000},
+		{"一萬五", 15000},
+		{"兩百五", 250},
+		{" 十二 ", 12},
+		{"九百二十二京三千三百七十二兆零三百六十八億五千四百七十七萬五千八百零七", math.MaxInt64},
+		{"負九百二十二京三千三百七十二兆零三百六十八億五千四百七十七萬五千八百零八", math.MinInt64},
+	}
+	for _, tt := range tests {
+		got, err := ParseChineseNumeral(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseChineseNumeral(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		in  string
+		err error
+	}{
+		{"", ErrSyntax},
+		{"負", ErrSyntax},
+		{"萬", ErrSyntax},
+		{"百", ErrSyntax},
+		{"一千千", ErrSyntax},
+		{"一萬億", ErrSyntax},
+		{"一二百", ErrSyntax},
+		{"三點一四", ErrSyntax},
+		{"abc", ErrSyntax},
+		{"九百二十二京三千三百七十二兆零三百六十八億五千四百七十七萬五千八百零八", ErrOverflow},
+		{"一千京", ErrOverflow},
+	} {
+		if _, err := ParseChineseNumeral(tt.in); !errors.Is(err, tt.err) {
+			t.Errorf("ParseChineseNumeral(%q) error = %v, want %v", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestChineseNumeralRoundTrip(t *testing.T) {
+	styles := []ChineseNumeralStyle{ChineseRegular, ChineseRegularSimplified, ChineseFinancial, ChineseFinancialSimplified}
+	for _, n := range []int64{1, 10, 19, 100, 1009, 10000, 10010, 100100, 20000000, 1000000001, 30405060708090, math.MaxInt64, math.MinInt64 + 1} {
+		for _, style := range styles {
+			s, err := FormatChineseNumeral(n, style)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := ParseChineseNumeral(s); err != nil || got != n {
+				t.Errorf("ParseChineseNumeral(%q) = %d, %v, want %d", s, got, err, n)
+			}
+		}
+	}
+}
+
+func BenchmarkFormatChineseNumeral(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = FormatChineseNumeral(int64(math.MaxInt64), ChineseFinancial)
+	}
+}
@@ -0,0 +1,63 @@
+package convert
+
+// The functions below are shorthands for To with a fixed numeric target.
+// Every source type accepted by To is supported, including pointers,
+// json.Number, time.Duration (as nanoseconds) and []byte (parsed as text).
+// Values outside the target range return ErrOverflow instead of being
+// clamped, and fractional floats return ErrTruncated for integer targets.
+
+// ToInt8 converts value to int8, validating the int8 range.
+func ToInt8(value any) (int8, error) {
+	return To[int8](value)
+}
+
+// ToInt16 converts value to int16, validating the int16 range.
+func ToInt16(value any) (int16, error) {
+	return To[int16](value)
+}
+
+// ToInt32 converts value to int32, validating the int32 range.
+func ToInt32(value any) (int32, error) {
+	return To[int32](value)
+}
+
+// ToInt64 converts value to int64, validating the int64 range.
+func ToInt64(value any) (int64, error) {
+	return To[int64](value)
+}
+
+// ToUint converts value to uint, validating the uint range.
+func ToUint(value any) (uint, error) {
+	return To[uint](value)
+}
+
+// ToUint8 converts value to uint8, validating the uint8 range.
+func ToUint8(value any) (uint8, error) {
+	return To[uint8](value)
+}
+
+// ToUint16 converts value to uint16, validating the uint16 range.
+func ToUint16(value any) (uint16, error) {
+	return To[uint16](value)
+}
+
+// ToUint32 converts value to uint32, validating the uint32 range.
+func ToUint32(value any) (uint32, error) {
+	return To[uint32](value)
+}
+
+// ToUint64 converts value to uint64, validating the uint64 range.
+func ToUint64(value any) (uint64, error) {
+	return To[uint64](value)
+}
+
+// ToFloat32 converts value to float32. Finite values beyond
+// ±math.MaxFloat32 return ErrOverflow.
+func ToFloat32(value any) (float32, error) {
+	return To[float32](value)
+}
+
+// ToFloat64 converts value to float64.
+func ToFloat64(value any) (float64, error) {
+	return To[float64](value)
+}
@@ -0,0 +1,256 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// intTarget describes an integer conversion under test and its valid range.
+type intTarget struct {
+	name     string
+	conv     func(any) (any, error)
+	min, max *big.Int
+}
+
+func wrap[T any](fn func(any) (T, error)) func(any) (any, error) {
+	return func(v any) (any, error) {
+		return fn(v)
+	}
+}
+
+func bigInt(v int64) *big.Int   { return big.NewInt(v) }
+func bigUint(v uint64) *big.Int { return new(big.Int).SetUint64(v) }
+
+var intTargets = []intTarget{
+	{"ToInt8", wrap(ToInt8), bigInt(math.MinInt8), bigInt(math.MaxInt8)},
+	{"ToInt16", wrap(ToInt16), bigInt(math.MinInt16), bigInt(math.MaxInt16)},
+	{"ToInt32", wrap(ToInt32), bigInt(math.MinInt32), bigInt(math.MaxInt32)},
+	{"ToInt64", wrap(ToInt64), bigInt(math.MinInt64), bigInt(math.MaxInt64)},
+	{"ToUint", wrap(ToUint), bigInt(0), bigUint(math.MaxUint)},
+	{"ToUint8", wrap(ToUint8), bigInt(0), bigUint(math.MaxUint8)},
+	{"ToUint16", wrap(ToUint16), bigInt(0), bigUint(math.MaxUint16)},
+	{"ToUint32", wrap(ToUint32), bigInt(0), bigUint(math.MaxUint32)},
+	{"ToUint64", wrap(ToUint64), bigInt(0), bigUint(math.MaxUint64)},
+}
+
+// sourceType builds a value of one source type from an integer, reporting
+// false when the integer cannot be represented exactly in that type.
+type sourceType struct {
+	name string
+	make func(*big.Int) (any, bool)
+}
+
+func fitsInt(v *big.Int, bits int) bool {
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	return v.Cmp(new(big.Int).Neg(limit)) >= 0 && v.Cmp(limit) < 0
+}
+
+func fitsUint(v *big.Int, bits int) bool {
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	return v.Sign() >= 0 && v.Cmp(limit) < 0
+}
+
+func signedSource[T int | int8 | int16 | int32 | int64 | time.Duration](name string, bits int) sourceType {
+	return sourceType{name, func(v *big.Int) (any, bool) {
+		if !fitsInt(v, bits) {
+			return nil, false
+		}
+		return T(v.Int64()), true
+	}}
+}
+
+func unsignedSource[T uint | uint8 | uint16 | uint32 | uint64](name string, bits int) sourceType {
+	return sourceType{name, func(v *big.Int) (any, bool) {
+		if !fitsUint(v, bits) {
+			return nil, false
+		}
+		return T(v.Uint64()), true
+	}}
+}
+
+var baseSources = []sourceType{
+	signedSource[int]("int", strconv.IntSize),
+	signedSource[int8]("int8", 8),
+	signedSource[int16]("int16", 16),
+	signedSource[int32]("int32", 32),
+	signedSource[int64]("int64", 64),
+	signedSource[time.Duration]("time.Duration", 64),
+	unsignedSource[uint]("uint", strconv.IntSize),
+	unsignedSource[uint8]("uint8", 8),
+	unsignedSource[uint16]("uint16", 16),
+	unsignedSource[uint32]("uint32", 32),
+	unsignedSource[uint64]("uint64", 64),
+	{"float32", func(v *big.Int) (any, bool) {
+		f, acc := new(big.Float).SetInt(v).Float32()
+		return f, acc == big.Exact
+	}},
+	{"float64", func(v *big.Int) (any, bool) {
+		f, acc := new(big.Float).SetInt(v).Float64()
+		return f, acc == big.Exact
+	}},
+	{"string", func(v *big.Int) (any, bool) { return v.String(), true }},
+	{"[]byte", func(v *big.Int) (any, bool) { return []byte(v.String()), true }},
+	{"json.Number", func(v *big.Int) (any, bool) { return json.Number(v.String()), true }},
+}
+
+// allSources adds a pointer variant for every base source type.
+func allSources() []sourceType {
+	sources := append([]sourceType(nil), baseSources...)
+	for _, s := range baseSources {
+		s := s
+		sources = append(sources, sourceType{"*" + s.name, func(v *big.Int) (any, bool) {
+			val, ok := s.make(v)
+			if !ok {
+				return nil, false
+			}
+			p := reflect.New(reflect.TypeOf(val))
+			p.Elem().Set(reflect.ValueOf(val))
+			return p.Interface(), true
+		}})
+	}
+	return sources
+}
+
+func resultToBig(v any) *big.Int {
+	rv := reflect.ValueOf(v)
+	if rv.CanInt() {
+		return big.NewInt(rv.Int())
+	}
+	return new(big.Int).SetUint64(rv.Uint())
+}
+
+func TestIntegerMatrix(t *testing.T) {
+	one := big.NewInt(1)
+	for _, target := range intTargets {
+		boundaries := []*big.Int{
+			target.min,
+			new(big.Int).Sub(target.min, one),
+			target.max,
+			new(big.Int).Add(target.max, one),
+			big.NewInt(0),
+			big.NewInt(-1),
+			big.NewInt(100),
+		}
+		for _, src := range allSources() {
+			for _, b := range boundaries {
+				value, ok := src.make(b)
+				if !ok {
+					continue
+				}
+				inRange := b.Cmp(target.min) >= 0 && b.Cmp(target.max) <= 0
+				got, err := target.conv(value)
+				switch {
+				case inRange && err != nil:
+					t.Errorf("%s(%s %v) error = %v", target.name, src.name, b, err)
+				case inRange && resultToBig(got).Cmp(b) != 0:
+					t.Errorf("%s(%s %v) = %v", target.name, src.name, b, got)
+				case !inRange && err == nil:
+					t.Errorf("%s(%s %v) = %v, want error", target.name, src.name, b, got)
+				case !inRange && !errors.Is(err, ErrOverflow) && !errors.Is(err, ErrSyntax):
+					// Negative strings for unsigned targets are a syntax error,
+					// everything else out of range is an overflow.
+					t.Errorf("%s(%s %v) error = %v, want ErrOverflow", target.name, src.name, b, err)
+				}
+			}
+		}
+	}
+}
+
+func TestFloatMatrix(t *testing.T) {
+	for _, src := range allSources() {
+		for _, b := range []*big.Int{bigInt(math.MinInt64), bigUint(math.MaxUint64), bigInt(-1), bigInt(0), bigInt(1 << 53)} {
+			value, ok := src.make(b)
+			if !ok {
+				continue
+			}
+			want, _ := new(big.Float).SetInt(b).Float64()
+			got64, err := ToFloat64(value)
+			if err != nil || got64 != want {
+				t.Errorf("ToFloat64(%s %v) = %v, %v, want %v", src.name, b, got64, err, want)
+			}
+			want32, _ := new(big.Float).SetInt(b).Float32()
+			got32, err := ToFloat32(value)
+			if err != nil || got32 != want32 {
+				t.Errorf("ToFloat32(%s %v) = %v, %v, want %v", src.name, b, got32, err, want32)
+			}
+		}
+	}
+}
+
+func TestToFloat32Boundaries(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  float32
+		err   error
+	}{
+		{"max float32", float64(math.MaxFloat32), math.MaxFloat32, nil},
+		{"min float32", -float64(math.MaxFloat32), -math.MaxFloat32, nil},
+		{"above max", math.MaxFloat32 * 2, 0, ErrOverflow},
+		{"below min", -math.MaxFloat32 * 2, 0, ErrOverflow},
+		{"string above max", "3.5e38", 0, ErrOverflow},
+		{"pointer above max", ToPtr(math.MaxFloat64), 0, ErrOverflow},
+		{"smallest nonzero", math.SmallestNonzeroFloat32, math.SmallestNonzeroFloat32, nil},
+		{"json number", json.Number("1.5"), 1.5, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToFloat32(tt.value)
+			if got != tt.want || !errors.Is(err, tt.err) {
+				t.Errorf("ToFloat32(%v) = %v, %v, want %v, %v", tt.value, got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestNumericSpecialSources(t *testing.T) {
+	if got, err := ToInt64(90 * time.Second); err != nil || got != int64(90*time.Second) {
+		t.Errorf("ToInt64(Duration) = %v, %v", got, err)
+	}
+	if _, err := ToInt32(time.Hour * 24 * 365); !errors.Is(err, ErrOverflow) {
+		t.Errorf("ToInt32(1 year) error = %v, want ErrOverflow", err)
+	}
+	if got, err := ToInt64(json.Number("9007199254740993")); err != nil || got != 9007199254740993 {
+		t.Errorf("ToInt64(json.Number) = %v, %v, want exact value", got, err)
+	}
+	if _, err := ToInt64(json.Number("1.5")); !errors.Is(err, ErrSyntax) {
+		t.Errorf("ToInt64(json.Number 1.5) error = %v, want ErrSyntax", err)
+	}
+	if _, err := ToUint8((*int)(nil)); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("ToUint8(nil pointer) error = %v, want ErrUnsupportedType", err)
+	}
+	if _, err := ToInt16(2.5); !errors.Is(err, ErrTruncated) {
+		t.Errorf("ToInt16(2.5) error = %v, want ErrTruncated", err)
+	}
+	if got, err := ToUint16([]byte("443")); err != nil || got != 443 {
+		t.Errorf("ToUint16([]byte) = %v, %v", got, err)
+	}
+}
+
+func TestToFloatWidenedSources(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{"int8", int8(-3), -3.0},
+		{"int64", int64(1 << 40), float64(1 << 40)},
+		{"uint16", uint16(7), 7.0},
+		{"*int", ToPtr(5), 5.0},
+		{"nil *int64", (*int64)(nil), 0.0},
+		{"nil *uint", (*uint)(nil), 0.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToFloat(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToFloat(%v) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
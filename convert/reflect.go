@@ -0,0 +1,123 @@
+package convert
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// reflectScalar normalizes values the type switch in toScalar does not
+// know about, such as named types (type Status int, type ID string) and
+// pointers or interfaces of any depth. It dispatches on reflect.Kind, so
+// it is only reached after the fast path has failed.
+func reflectScalar(value any) (scalar, bool) {
+	rv, ok := indirect(reflect.ValueOf(value))
+	if !ok {
+		return scalar{}, false
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return scalar{kind: kindInt, i: rv.Int()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return scalar{kind: kindUint, u: rv.Uint()}, true
+	case reflect.Float32, reflect.Float64:
+		return scalar{kind: kindFloat, f: rv.Float(), bits: rv.Type().Bits()}, true
+	case reflect.Bool:
+		return scalar{kind: kindBool, b: rv.Bool()}, true
+	case reflect.String:
+		return scalar{kind: kindString, s: rv.String()}, true
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return scalar{kind: kindString, s: string(rv.Bytes())}, true
+		}
+	}
+	return scalar{}, false
+}
+
+// indirect follows pointers and interfaces until it reaches a concrete
+// value. It reports false for nil values at any depth.
+func indirect(rv reflect.Value) (reflect.Value, bool) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return rv, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.IsValid()
+}
+
+// basicZero maps a kind to the zero value of its predeclared type, so a
+// named target type can reuse the conversion of its underlying type.
+var basicZero = map[reflect.Kind]any{
+	reflect.Bool:    false,
+	reflect.String:  "",
+	reflect.Int:     int(0),
+	reflect.Int8:    int8(0),
+	reflect.Int16:   int16(0),
+	reflect.Int32:   int32(0),
+	reflect.Int64:   int64(0),
+	reflect.Uint:    uint(0),
+	reflect.Uint8:   uint8(0),
+	reflect.Uint16:  uint16(0),
+	reflect.Uint32:  uint32(0),
+	reflect.Uint64:  uint64(0),
+	reflect.Float32: float32(0),
+	reflect.Float64: float64(0),
+}
+
+// convertToNamed converts value to a named target type by converting to
+// the underlying predeclared type first.
+func convertToNamed(value, target any) (any, error) {
+	rt := reflect.TypeOf(target)
+	if rt == nil {
+		return nil, ErrUnsupportedType
+	}
+	zero, ok := basicZero[rt.Kind()]
+	if !ok || rt == reflect.TypeOf(zero) {
+		return nil, ErrUnsupportedType
+	}
+	out, err := convertTo(value, zero)
+	if err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(out).Convert(rt).Interface(), nil
+}
+
+// formatValue is the slow path of ToString. It honors driver.Valuer,
+// fmt.Stringer and encoding.TextMarshaler, and formats the target of a
+// pointer rather than its address. Errors and fmt.Formatter values are
+// left to fmt.
+func formatValue(value any) string {
+	for {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "<nil>"
+		}
+		switch v := value.(type) {
+		case driver.Valuer:
+			dv, err := v.Value()
+			if err != nil {
+				return fmt.Sprintf("%v", value)
+			}
+			if b, ok := dv.([]byte); ok {
+				return string(b)
+			}
+			return ToString(dv)
+		case fmt.Stringer:
+			return v.String()
+		case error, fmt.Formatter:
+			return fmt.Sprintf("%v", v)
+		case encoding.TextMarshaler:
+			text, err := v.MarshalText()
+			if err != nil {
+				return fmt.Sprintf("%v", value)
+			}
+			return string(text)
+		}
+		if rv.Kind() != reflect.Pointer {
+			return fmt.Sprintf("%v", value)
+		}
+		value = rv.Elem().Interface()
+	}
+}
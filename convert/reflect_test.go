@@ -0,0 +1,170 @@
+package convert
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type (
+	status   int
+	userID   string
+	flag     bool
+	ratio    float32
+	count    uint16
+	rawBytes []byte
+)
+
+type temperature float64
+
+func (t temperature) String() string { return ToString(float64(t)) + "°C" }
+
+type money struct{ cents int64 }
+
+func (m money) Value() (driver.Value, error) { return m.cents, nil }
+
+type brokenValuer struct{}
+
+func (brokenValuer) Value() (driver.Value, error) { return nil, errors.New("broken") }
+
+func TestReflectFallback(t *testing.T) {
+	s := status(7)
+	ps := &s
+	pps := &ps
+	var iface any = userID("42")
+
+	tests := []struct {
+		name  string
+		value any
+		int   any
+		bool  bool
+		float any
+	}{
+		{"named int", status(3), 3, true, 3.0},
+		{"named string", userID("12"), 12, true, 12.0},
+		{"named bool", flag(true), 1, true, 1.0},
+		{"named float32", ratio(2.5), 2, true, float32(2.5)},
+		{"named uint", count(0), 0, false, 0.0},
+		{"named bytes", rawBytes("5"), 5, true, 5.0},
+		{"pointer to named", ps, 7, true, 7.0},
+		{"pointer to pointer", pps, 7, true, 7.0},
+		{"pointer to interface", &iface, 42, true, 42.0},
+		{"duration", 3 * time.Second, nil, true, float64(3 * time.Second)},
+		{"unsupported", struct{}{}, nil, false, 0.0},
+		{"nil pointer to named", (*status)(nil), nil, false, 0.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToInt(tt.value); !reflect.DeepEqual(got, tt.int) {
+				t.Errorf("ToInt() = %#v, want %#v", got, tt.int)
+			}
+			if got := ToBool(tt.value); got != tt.bool {
+				t.Errorf("ToBool() = %v, want %v", got, tt.bool)
+			}
+			if got := ToFloat(tt.value); !reflect.DeepEqual(got, tt.float) {
+				t.Errorf("ToFloat() = %#v, want %#v", got, tt.float)
+			}
+		})
+	}
+}
+
+func TestToStringInterfaces(t *testing.T) {
+	str := "hello"
+	n := 5
+	pn := &n
+	addr := netip.MustParseAddr("192.0.2.1")
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"string", "plain", "plain"},
+		{"pointer to string", &str, "hello"},
+		{"nil pointer to string", (*string)(nil), "<nil>"},
+		{"nil", nil, "<nil>"},
+		{"pointer to int", pn, "5"},
+		{"pointer to pointer", &pn, "5"},
+		{"nil pointer to int", (*int)(nil), "<nil>"},
+		{"named string", userID("u-1"), "u-1"},
+		{"stringer", temperature(21.5), "21.5°C"},
+		{"pointer to stringer", ToPtr(temperature(3)), "3°C"},
+		{"text marshaler", &addr, "192.0.2.1"},
+		{"valuer", money{cents: 1999}, "1999"},
+		{"null string", sql.NullString{String: "x", Valid: true}, "x"},
+		{"invalid null string", sql.NullString{}, "<nil>"},
+		{"valuer error", brokenValuer{}, "{}"},
+		{"error", errors.New("boom"), "boom"},
+		{"duration", 90 * time.Second, "1m30s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToString(tt.value); got != tt.want {
+				t.Errorf("ToString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToNamedTarget(t *testing.T) {
+	got, err := To[status]("12")
+	if err != nil || got != status(12) {
+		t.Errorf("To[status](\"12\") = %v, %v", got, err)
+	}
+	id, err := To[userID](42)
+	if err != nil || id != userID("42") {
+		t.Errorf("To[userID](42) = %v, %v", id, err)
+	}
+	c, err := To[count](status(9))
+	if err != nil || c != count(9) {
+		t.Errorf("To[count](status(9)) = %v, %v", c, err)
+	}
+	if _, err := To[count](-1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("To[count](-1) error = %v, want ErrOverflow", err)
+	}
+	var cerr *ConversionError
+	if _, err := To[time.Time]("now"); !errors.As(err, &cerr) || !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("To[time.Time] error = %v, want ErrUnsupportedType", err)
+	}
+	if _, err := To[error](1); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("To[error] error = %v, want ErrUnsupportedType", err)
+	}
+}
+
+var (
+	intSink    any
+	stringOut  string
+	namedValue = status(42)
+)
+
+func BenchmarkToIntBasic(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		intSink = ToInt(42)
+	}
+}
+
+func BenchmarkToIntNamed(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		intSink = ToInt(namedValue)
+	}
+}
+
+func BenchmarkToStringInt(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		stringOut = ToString(12345)
+	}
+}
+
+func BenchmarkToStringStringer(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		stringOut = ToString(temperature(21.5))
+	}
+}
@@ -0,0 +1,154 @@
+package convert
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// slugLetters are Latin letters that are spelled out in ASCII in slugs.
+var slugLetters = map[rune]string{
+	'ß': "ss", 'ẞ': "SS", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+	'þ': "th", 'Þ': "TH", 'ð': "d", 'Ð': "D",
+}
+
+/*
+Slugifier turns text into URL slugs. The text is normalized with NFKC, which
+folds full-width characters and ligatures, diacritics are removed with
+RemoveDiacritics and letters such as "ß" are spelled out. Letters, digits
+and the marks of other scripts form words; everything else separates them.
+Apostrophes are dropped without separating, so "don't" becomes "dont".
+
+Letters without an ASCII equivalent, such as Chinese, are kept unless
+ASCIIOnly is set, since they are valid in URLs once percent-encoded. Set
+Transliterate to romanize them instead.
+
+The zero value is ready to use and produces lower case slugs joined by "-".
+
+Example:
+
+	s := convert.Slugifier{Separator: "_", MaxLength: 16}
+	s.Slug("Crème Brûlée: 10 Recipes!") // "creme_brulee_10"
+*/
+type Slugifier struct {
+	// Separator joins the words. The default is "-".
+	Separator string
+	// MaxLength limits the slug to this many bytes, cutting at a word
+	// boundary when the first word fits. Zero means no limit.
+	MaxLength int
+	// KeepCase keeps upper case letters instead of lowering them.
+	KeepCase bool
+	// ASCIIOnly drops letters and digits that are still not ASCII after
+	// transliteration.
+	ASCIIOnly bool
+	// Transliterate, if set, is called with each run of letters that have
+	// no ASCII form, such as "北京", and returns its romanization, such as
+	// "bei jing". The result is slugified in place of the run, as separate
+	// words.
+	Transliterate func(string) string
+}
+
+// Slug returns the slug of s.
+func (sl *Slugifier) Slug(s string) string {
+	s = RemoveDiacritics(NFKC(s))
+	if sl.Transliterate != nil {
+		s = sl.transliterate(s)
+	}
+	if !sl.KeepCase {
+		s = strings.ToLower(s)
+	}
+	sep := sl.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	split := false
+	for _, r := range s {
+		if r == '\'' || r == '’' {
+			continue
+		}
+		word, ok := slugLetters[r]
+		if !ok {
+			if !isSlugRune(r) || (sl.ASCIIOnly && r >= utf8.RuneSelf) {
+				split = true
+				continue
+			}
+		}
+		if split && b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		split = false
+		if ok {
+			b.WriteString(word)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return truncateSlug(b.String(), sep, sl.MaxLength)
+}
+
+// transliterate replaces each run of letters that have no ASCII form with
+// its romanization, surrounded by spaces so it forms separate words.
+func (sl *Slugifier) transliterate(s string) string {
+	var b strings.Builder
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			b.WriteByte(' ')
+			b.WriteString(RemoveDiacritics(sl.Transliterate(s[start:end])))
+			b.WriteByte(' ')
+			start = -1
+		}
+	}
+	for i, r := range s {
+		_, spelled := slugLetters[r]
+		if r >= utf8.RuneSelf && !spelled && isSlugRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+		b.WriteRune(r)
+	}
+	flush(len(s))
+	return b.String()
+}
+
+func isSlugRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// truncateSlug cuts slug to at most n bytes, at the last separator if
+// there is one and otherwise at a character boundary.
+func truncateSlug(slug, sep string, n int) string {
+	if n <= 0 || len(slug) <= n {
+		return slug
+	}
+	if strings.HasPrefix(slug[n:], sep) {
+		return slug[:n]
+	}
+	if i := strings.LastIndex(slug[:n], sep); i > 0 {
+		return slug[:i]
+	}
+	for n > 0 && !utf8.RuneStart(slug[n]) {
+		n--
+	}
+	return slug[:n]
+}
+
+/*
+Slugify returns a lower case URL slug of s with words joined by "-", using
+the zero Slugifier.
+
+Example:
+
+	convert.Slugify("Crème Brûlée: 10 Recipes!") // "creme-brulee-10-recipes"
+	convert.Slugify("Ｈｅｌｌｏ　世界")                  // "hello-世界"
+*/
+func Slugify(s string) string {
+	var sl Slugifier
+	return sl.Slug(s)
+}
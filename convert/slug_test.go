@@ -0,0 +1,77 @@
+package convert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"Hello World", "hello-world"},
+		{"  --Hello,   World!--  ", "hello-world"},
+		{"Crème Brûlée: 10 Recipes!", "creme-brulee-10-recipes"},
+		{"Ｈｅｌｌｏ　世界", "hello-世界"},
+		{"Straße & Æsir", "strasse-aesir"},
+		{"don't stop", "dont-stop"},
+		{"ﬁle²", "file2"},
+		{"ｱｲｳ カナ", "アイウ-カナ"},
+		{"Привет, мир", "привет-мир"},
+		{"!!!", ""},
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.in); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifierOptions(t *testing.T) {
+	pinyin := map[string]string{"北京": "Běi Jīng", "你好": "nǐ hǎo"}
+	tests := []struct {
+		name string
+		sl   Slugifier
+		in   string
+		want string
+	}{
+		{"separator", Slugifier{Separator: "_"}, "Hello World", "hello_world"},
+		{"keep case", Slugifier{KeepCase: true}, "Hello World", "Hello-World"},
+		{"ASCII only", Slugifier{ASCIIOnly: true}, "Hello 世界 again", "hello-again"},
+		{"max length at word", Slugifier{MaxLength: 16}, "Crème Brûlée: 10 Recipes!", "creme-brulee-10"},
+		{"max length exact", Slugifier{MaxLength: 11}, "hello world again", "hello-world"},
+		{"max length long word", Slugifier{MaxLength: 4}, "abcdefgh", "abcd"},
+		{"max length rune boundary", Slugifier{MaxLength: 4}, "世界", "世"},
+		{
+			"transliterate",
+			Slugifier{Transliterate: func(s string) string { return pinyin[s] }},
+			"北京city, 你好!",
+			"bei-jing-city-ni-hao",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.sl.Slug(tt.in); got != tt.want {
+			t.Errorf("%s: Slug(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifierTransliterateRuns(t *testing.T) {
+	var runs []string
+	sl := Slugifier{Transliterate: func(s string) string {
+		runs = append(runs, s)
+		return "x"
+	}}
+	sl.Slug("Café 北京 and 東京タワー")
+	if got := strings.Join(runs, ","); got != "北京,東京タワー" {
+		t.Errorf("Transliterate called with %q, want %q", got, "北京,東京タワー")
+	}
+}
+
+func BenchmarkSlugify(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = Slugify("Crème Brûlée: 10 Recipes for the Ｈｏｌｉｄａｙｓ!")
+	}
+}
@@ -0,0 +1,405 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/appleboy/com/bytesconv"
+)
+
+var (
+	// ErrSyntax indicates that a string does not have the right syntax for
+	// the target type.
+	ErrSyntax = errors.New("invalid syntax")
+	// ErrOverflow indicates that a value is out of range for the target type.
+	ErrOverflow = errors.New("value out of range")
+	// ErrTruncated indicates that converting a value would drop its
+	// fractional part.
+	ErrTruncated = errors.New("value has a fractional part")
+	// ErrUnsupportedType indicates that the source or target type is not
+	// supported by the conversion.
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// ConversionError records a failed strict conversion together with the
+// source value and the requested target type. Use errors.Is with
+// ErrSyntax, ErrOverflow, ErrTruncated or ErrUnsupportedType to check the
+// reason.
+type ConversionError struct {
+	Value  any
+	Target reflect.Type
+	Err    error
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("convert: cannot convert %#v (%T) to %v: %v", e.Value, e.Value, e.Target, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error { return e.Err }
+
+/*
+To converts value to T and reports failures instead of guessing.
+
+Supported targets are bool, string, the sized and unsized integer types,
+float32/float64 and named types based on them, such as type Status int.
+Unlike ToInt, ToBool and ToFloat, To never clamps, rounds or falls back to
+a zero value:
+  - integers must fit the target range (ErrOverflow)
+  - floats converted to integers must be whole numbers (ErrTruncated)
+  - strings must parse with strconv rules for the target (ErrSyntax)
+  - numbers converted to bool must be 0 or 1
+
+Every error is a *ConversionError.
+
+Example:
+
+	port, err := convert.To[uint16]("8080") // 8080, nil
+	_, err = convert.To[int8](300)          // errors.Is(err, convert.ErrOverflow)
+*/
+func To[T any](value any) (T, error) {
+	var zero T
+	out, err := convertTo(value, any(zero))
+	if err != nil {
+		return zero, &ConversionError{Value: value, Target: reflect.TypeFor[T](), Err: err}
+	}
+	return out.(T), nil
+}
+
+// convertTo converts value to the dynamic type of target.
+func convertTo(value, target any) (any, error) {
+	switch target.(type) {
+	case bool:
+		return toBoolStrict(value)
+	case string:
+		return toStringStrict(value)
+	case int:
+		v, err := toSigned(value, strconv.IntSize)
+		return int(v), err
+	case int8:
+		v, err := toSigned(value, 8)
+		return int8(v), err // #nosec G115 -- range validated by toSigned
+	case int16:
+		v, err := toSigned(value, 16)
+		return int16(v), err // #nosec G115 -- range validated by toSigned
+	case int32:
+		v, err := toSigned(value, 32)
+		return int32(v), err // #nosec G115 -- range validated by toSigned
+	case int64:
+		return toSigned(value, 64)
+	case uint:
+		v, err := toUnsigned(value, strconv.IntSize)
+		return uint(v), err
+	case uint8:
+		v, err := toUnsigned(value, 8)
+		return uint8(v), err // #nosec G115 -- range validated by toUnsigned
+	case uint16:
+		v, err := toUnsigned(value, 16)
+		return uint16(v), err // #nosec G115 -- range validated by toUnsigned
+	case uint32:
+		v, err := toUnsigned(value, 32)
+		return uint32(v), err // #nosec G115 -- range validated by toUnsigned
+	case uint64:
+		return toUnsigned(value, 64)
+	case float32:
+		v, err := toFloatStrict(value, 32)
+		return float32(v), err
+	case float64:
+		return toFloatStrict(value, 64)
+	}
+	return convertToNamed(value, target)
+}
+
+// scalarKind classifies the normalized form of a source value.
+type scalarKind int
+
+const (
+	kindInvalid scalarKind = iota
+	kindInt
+	kindUint
+	kindFloat
+	kindBool
+	kindString
+)
+
+// scalar is a source value reduced to one of the basic kinds.
+type scalar struct {
+	kind scalarKind
+	i    int64
+	u    uint64
+	f    float64
+	// bits is the precision of f, used to format float32 values exactly.
+	bits int
+	b    bool
+	s    string
+}
+
+// toScalar normalizes the supported source types: the basic types,
+// []byte, json.Number, time.Duration and pointers to any of them. Other
+// types go through reflectScalar.
+func toScalar(value any) (scalar, bool) {
+	switch v := value.(type) {
+	case int:
+		return scalar{kind: kindInt, i: int64(v)}, true
+	case int8:
+		return scalar{kind: kindInt, i: int64(v)}, true
+	case int16:
+		return scalar{kind: kindInt, i: int64(v)}, true
+	case int32:
+		return scalar{kind: kindInt, i: int64(v)}, true
+	case int64:
+		return scalar{kind: kindInt, i: v}, true
+	case uint:
+		return scalar{kind: kindUint, u: uint64(v)}, true
+	case uint8:
+		return scalar{kind: kindUint, u: uint64(v)}, true
+	case uint16:
+		return scalar{kind: kindUint, u: uint64(v)}, true
+	case uint32:
+		return scalar{kind: kindUint, u: uint64(v)}, true
+	case uint64:
+		return scalar{kind: kindUint, u: v}, true
+	case float32:
+		return scalar{kind: kindFloat, f: float64(v), bits: 32}, true
+	case float64:
+		return scalar{kind: kindFloat, f: v, bits: 64}, true
+	case bool:
+		return scalar{kind: kindBool, b: v}, true
+	case string:
+		return scalar{kind: kindString, s: v}, true
+	case []byte:
+		return scalar{kind: kindString, s: string(v)}, true
+	case json.Number:
+		return scalar{kind: kindString, s: string(v)}, true
+	case time.Duration:
+		return scalar{kind: kindInt, i: int64(v)}, true
+	case *int:
+		return derefScalar(v)
+	case *int8:
+		return derefScalar(v)
+	case *int16:
+		return derefScalar(v)
+	case *int32:
+		return derefScalar(v)
+	case *int64:
+		return derefScalar(v)
+	case *uint:
+		return derefScalar(v)
+	case *uint8:
+		return derefScalar(v)
+	case *uint16:
+		return derefScalar(v)
+	case *uint32:
+		return derefScalar(v)
+	case *uint64:
+		return derefScalar(v)
+	case *float32:
+		return derefScalar(v)
+	case *float64:
+		return derefScalar(v)
+	case *bool:
+		return derefScalar(v)
+	case *string:
+		return derefScalar(v)
+	case *[]byte:
+		return derefScalar(v)
+	case *json.Number:
+		return derefScalar(v)
+	case *time.Duration:
+		return derefScalar(v)
+	}
+	return reflectScalar(value)
+}
+
+// derefScalar normalizes the value p points to. A nil pointer is not
+// convertible.
+func derefScalar[T any](p *T) (scalar, bool) {
+	if p == nil {
+		return scalar{}, false
+	}
+	return toScalar(*p)
+}
+
+// toSigned converts value to a signed integer of the given bit size.
+func toSigned(value any, bits int) (int64, error) {
+	s, ok := toScalar(value)
+	if !ok {
+		return 0, ErrUnsupportedType
+	}
+	maxVal := int64(1)<<(bits-1) - 1
+	minVal := -maxVal - 1
+	switch s.kind {
+	case kindInt:
+		if s.i < minVal || s.i > maxVal {
+			return 0, ErrOverflow
+		}
+		return s.i, nil
+	case kindUint:
+		if s.u > uint64(maxVal) {
+			return 0, ErrOverflow
+		}
+		return int64(s.u), nil // #nosec G115 -- range validated above
+	case kindFloat:
+		if math.IsNaN(s.f) || s.f < float64(minVal) || s.f >= -float64(minVal) {
+			return 0, ErrOverflow
+		}
+		if s.f != math.Trunc(s.f) {
+			return 0, ErrTruncated
+		}
+		return int64(s.f), nil
+	case kindBool:
+		return int64(boolToInt(s.b)), nil
+	case kindString:
+		v, err := bytesconv.ParseInt(bytesconv.StrToBytes(s.s), 10, bits)
+		if err != nil {
+			return 0, numericError(err)
+		}
+		return v, nil
+	case kindInvalid:
+	}
+	return 0, ErrUnsupportedType
+}
+
+// toUnsigned converts value to an unsigned integer of the given bit size.
+func toUnsigned(value any, bits int) (uint64, error) {
+	s, ok := toScalar(value)
+	if !ok {
+		return 0, ErrUnsupportedType
+	}
+	// For 64 bits the shift yields 0, so maxVal wraps to MaxUint64.
+	maxVal := uint64(1)<<bits - 1
+	switch s.kind {
+	case kindInt:
+		if s.i < 0 || uint64(s.i) > maxVal {
+			return 0, ErrOverflow
+		}
+		return uint64(s.i), nil
+	case kindUint:
+		if s.u > maxVal {
+			return 0, ErrOverflow
+		}
+		return s.u, nil
+	case kindFloat:
+		// float64(maxVal) rounds up to a power of two for 64 bits, which is
+		// itself out of range, hence >=.
+		if math.IsNaN(s.f) || s.f < 0 || s.f >= float64(maxVal)+1 {
+			return 0, ErrOverflow
+		}
+		if s.f != math.Trunc(s.f) {
+			return 0, ErrTruncated
+		}
+		return uint64(s.f), nil
+	case kindBool:
+		return uint64(boolToInt(s.b)), nil // #nosec G115 -- 0 or 1
+	case kindString:
+		v, err := bytesconv.ParseUint(bytesconv.StrToBytes(s.s), 10, bits)
+		if err != nil {
+			return 0, numericError(err)
+		}
+		return v, nil
+	case kindInvalid:
+	}
+	return 0, ErrUnsupportedType
+}
+
+// toFloatStrict converts value to a float of the given bit size.
+func toFloatStrict(value any, bits int) (float64, error) {
+	s, ok := toScalar(value)
+	if !ok {
+		return 0, ErrUnsupportedType
+	}
+	var f float64
+	switch s.kind {
+	case kindInt:
+		f = float64(s.i)
+	case kindUint:
+		f = float64(s.u)
+	case kindFloat:
+		f = s.f
+	case kindBool:
+		f = float64(boolToInt(s.b))
+	case kindString:
+		v, err := bytesconv.ParseFloat(bytesconv.StrToBytes(s.s), bits)
+		if err != nil {
+			return 0, numericError(err)
+		}
+		return v, nil
+	case kindInvalid:
+		return 0, ErrUnsupportedType
+	}
+	if bits == 32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+		return 0, ErrOverflow
+	}
+	return f, nil
+}
+
+// toBoolStrict converts value to a bool. Strings use strconv.ParseBool
+// syntax and numbers must be exactly 0 or 1.
+func toBoolStrict(value any) (bool, error) {
+	s, ok := toScalar(value)
+	if !ok {
+		return false, ErrUnsupportedType
+	}
+	switch s.kind {
+	case kindBool:
+		return s.b, nil
+	case kindString:
+		v, err := bytesconv.ParseBool(bytesconv.StrToBytes(s.s))
+		if err != nil {
+			return false, ErrSyntax
+		}
+		return v, nil
+	case kindInt:
+		return zeroOrOne(s.i == 0, s.i == 1)
+	case kindUint:
+		return zeroOrOne(s.u == 0, s.u == 1)
+	case kindFloat:
+		return zeroOrOne(s.f == 0, s.f == 1)
+	case kindInvalid:
+	}
+	return false, ErrUnsupportedType
+}
+
+func zeroOrOne(isZero, isOne bool) (bool, error) {
+	switch {
+	case isZero:
+		return false, nil
+	case isOne:
+		return true, nil
+	}
+	return false, ErrOverflow
+}
+
+// toStringStrict formats a supported value as a string.
+func toStringStrict(value any) (string, error) {
+	s, ok := toScalar(value)
+	if !ok {
+		return "", ErrUnsupportedType
+	}
+	switch s.kind {
+	case kindString:
+		return s.s, nil
+	case kindInt:
+		return strconv.FormatInt(s.i, 10), nil
+	case kindUint:
+		return strconv.FormatUint(s.u, 10), nil
+	case kindFloat:
+		return strconv.FormatFloat(s.f, 'g', -1, s.bits), nil
+	case kindBool:
+		return strconv.FormatBool(s.b), nil
+	case kindInvalid:
+	}
+	return "", ErrUnsupportedType
+}
+
+// numericError maps a bytesconv parse error to ErrOverflow or ErrSyntax.
+func numericError(err error) error {
+	if errors.Is(err, bytesconv.ErrRange) {
+		return ErrOverflow
+	}
+	return ErrSyntax
+}
@@ -0,0 +1,157 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type strictCase[T any] struct {
+	name  string
+	value any
+	want  T
+	err   error
+}
+
+func runStrict[T any](t *testing.T, tests []strictCase[T]) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := To[T](tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("To[%T](%#v) error = %v, want %v", tt.want, tt.value, err, tt.err)
+			}
+			if err != nil {
+				var cerr *ConversionError
+				if !errors.As(err, &cerr) {
+					t.Fatalf("error %T is not a *ConversionError", err)
+				}
+				if cerr.Target != reflect.TypeFor[T]() || fmt.Sprint(cerr.Value) != fmt.Sprint(tt.value) {
+					t.Errorf("ConversionError = %+v", cerr)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("To[%T](%#v) = %#v, want %#v", tt.want, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToInt64Strict(t *testing.T) {
+	runStrict(t, []strictCase[int64]{
+		{"int", 42, 42, nil},
+		{"int8 min", int8(math.MinInt8), math.MinInt8, nil},
+		{"uint64 max int64", uint64(math.MaxInt64), math.MaxInt64, nil},
+		{"uint64 overflow", uint64(math.MaxInt64) + 1, 0, ErrOverflow},
+		{"whole float", 1e15, 1e15, nil},
+		{"fractional float", 1.5, 0, ErrTruncated},
+		{"float overflow", 1e19, 0, ErrOverflow},
+		{"NaN", math.NaN(), 0, ErrOverflow},
+		{"bool", true, 1, nil},
+		{"string", "-9223372036854775808", math.MinInt64, nil},
+		{"string overflow", "9223372036854775808", 0, ErrOverflow},
+		{"string syntax", "12abc", 0, ErrSyntax},
+		{"string float syntax", "1.0", 0, ErrSyntax},
+		{"nil", nil, 0, ErrUnsupportedType},
+		{"struct", struct{}{}, 0, ErrUnsupportedType},
+	})
+}
+
+func TestToInt8Strict(t *testing.T) {
+	runStrict(t, []strictCase[int8]{
+		{"max", 127, 127, nil},
+		{"min", -128, -128, nil},
+		{"above max", 128, 0, ErrOverflow},
+		{"below min", -129, 0, ErrOverflow},
+		{"string above max", "128", 0, ErrOverflow},
+		{"float", -128.0, -128, nil},
+	})
+}
+
+func TestToUint16Strict(t *testing.T) {
+	runStrict(t, []strictCase[uint16]{
+		{"port string", "8080", 8080, nil},
+		{"max", 65535, 65535, nil},
+		{"overflow", 65536, 0, ErrOverflow},
+		{"negative", -1, 0, ErrOverflow},
+		{"negative string", "-1", 0, ErrSyntax},
+		{"negative float", -1.0, 0, ErrOverflow},
+	})
+}
+
+func TestToUint64Strict(t *testing.T) {
+	runStrict(t, []strictCase[uint64]{
+		{"max string", "18446744073709551615", math.MaxUint64, nil},
+		{"overflow string", "18446744073709551616", 0, ErrOverflow},
+		{"float at 2^64", math.Pow(2, 64), 0, ErrOverflow},
+	})
+}
+
+func TestToIntStrict(t *testing.T) {
+	runStrict(t, []strictCase[int]{
+		{"int64", int64(7), 7, nil},
+		{"string", "100", 100, nil},
+	})
+}
+
+func TestToFloatStrict(t *testing.T) {
+	runStrict(t, []strictCase[float64]{
+		{"int", 3, 3, nil},
+		{"float32", float32(0.5), 0.5, nil},
+		{"string", "3.25", 3.25, nil},
+		{"string exponent", "1e3", 1000, nil},
+		{"string overflow", "1e400", 0, ErrOverflow},
+		{"string syntax", "pi", 0, ErrSyntax},
+		{"bool", false, 0, nil},
+	})
+	runStrict(t, []strictCase[float32]{
+		{"fits", 1.5, 1.5, nil},
+		{"overflow", math.MaxFloat64, 0, ErrOverflow},
+		{"infinity", math.Inf(1), float32(math.Inf(1)), nil},
+		{"string overflow", "1e39", 0, ErrOverflow},
+	})
+}
+
+func TestToBoolStrict(t *testing.T) {
+	runStrict(t, []strictCase[bool]{
+		{"bool", true, true, nil},
+		{"string true", "true", true, nil},
+		{"string 0", "0", false, nil},
+		{"string no", "no", false, ErrSyntax},
+		{"string empty", "", false, ErrSyntax},
+		{"int 1", 1, true, nil},
+		{"int 0", 0, false, nil},
+		{"int 2", 2, false, ErrOverflow},
+		{"float 1", 1.0, true, nil},
+	})
+}
+
+func TestToStringStrict(t *testing.T) {
+	runStrict(t, []strictCase[string]{
+		{"string", "x", "x", nil},
+		{"int", -5, "-5", nil},
+		{"uint64", uint64(math.MaxUint64), "18446744073709551615", nil},
+		{"float32", float32(23.03), "23.03", nil},
+		{"float64", 0.1, "0.1", nil},
+		{"bool", true, "true", nil},
+		{"slice", []int{1}, "", ErrUnsupportedType},
+	})
+}
+
+func TestToUnsupportedTarget(t *testing.T) {
+	_, err := To[[]string]("a")
+	if !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("To[[]string] error = %v, want %v", err, ErrUnsupportedType)
+	}
+}
+
+func TestConversionErrorMessage(t *testing.T) {
+	_, err := To[int8]("300")
+	want := `convert: cannot convert "300" (string) to int8: value out of range`
+	if err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
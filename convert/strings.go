@@ -12,6 +12,9 @@ import (
 MD5Hash computes the SHA-256 hash of the input string and returns a 64-character hexadecimal string.
 - Useful for data validation, generating unique identifiers, etc.
 - Note: Uses SHA-256, a cryptographically secure hash function.
+
+Deprecated: despite its name MD5Hash computes SHA-256. Use Hash or HashString
+with SHA256, or with MD5 if MD5 is really what you need.
 */
 func MD5Hash(text string) string {
 	hasher := sha256.New()
@@ -24,6 +27,7 @@ SnakeCasedName converts a string to snake_case format and supports Unicode chara
 - Each uppercase English letter (A-Z) is converted to lowercase and prefixed with an underscore if not at the start.
 - Only English letters are affected; other Unicode characters (e.g., Chinese) are preserved as-is.
 - Example: FooBar -> foo_bar, 你好World -> 你好_world
+- Acronyms are split letter by letter (HTTPServer -> h_t_t_p_server); use ToSnake to keep them together.
 */
 func SnakeCasedName(name string) string {
 	var b strings.Builder
@@ -71,6 +75,7 @@ func TitleCasedName(name string) string {
 Float64ToByte converts a float64 value to an 8-byte slice in BigEndian order.
 - Useful for binary serialization and network transmission.
 - Reference: https://stackoverflow.com/questions/43693360/convert-float64-to-byte-array
+- See ToBytes for other numeric types and byte orders.
 */
 func Float64ToByte(f float64) []byte {
 	var buf [8]byte
@@ -82,6 +87,7 @@ func Float64ToByte(f float64) []byte {
 ByteToFloat64 converts an 8-byte slice in BigEndian order back to a float64 value.
 - Panics if the input length is not 8.
 - Useful for binary deserialization and network data parsing.
+- See FromBytes for a variant that returns an error instead of panicking.
 */
 func ByteToFloat64(bytes []byte) float64 {
 	if len(bytes) != 8 {
@@ -0,0 +1,296 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appleboy/com/random"
+)
+
+// ErrChecksum is returned by ValidateTaiwanID and ValidateBusinessNumber
+// when the input is well formed but its check digit is wrong.
+var ErrChecksum = errors.New("convert: checksum mismatch")
+
+// rocEpoch is the Gregorian year before ROC year 1 (1912).
+const rocEpoch = 1911
+
+// Layouts for FormatROC and ParseROC. "2006" stands for the ROC year.
+const (
+	ROCLongDate    = "民國2006年1月2日" // 民國113年5月20日
+	ROCSlashDate   = "2006/01/02"  // 113/05/20
+	ROCCompactDate = "20060102"    // 1130520, the 7-digit form used by government systems
+)
+
+// ROCYear returns the ROC (Minguo) year of the Gregorian year: 113 for 2024.
+// There is no year zero; years before 1912 are negative, so 1911 is -1,
+// written 民國前1年.
+func ROCYear(year int) int {
+	if year > rocEpoch {
+		return year - rocEpoch
+	}
+	return year - rocEpoch - 1
+}
+
+// GregorianYear returns the Gregorian year of an ROC year as numbered by
+// ROCYear. It fails with ErrOverflow for year zero, which does not exist.
+func GregorianYear(rocYear int) (int, error) {
+	switch {
+	case rocYear > 0:
+		return rocYear + rocEpoch, nil
+	case rocYear < 0:
+		return rocYear + rocEpoch + 1, nil
+	}
+	return 0, &ConversionError{Value: rocYear, Target: reflect.TypeFor[int](), Err: ErrOverflow}
+}
+
+/*
+FormatROC formats t like time.Format, except that "2006" in layout is the ROC
+year instead of the Gregorian one. Years before 1912 are written with the
+prefix 前, as in 民國前1年. When the year is directly followed by another
+number, as in ROCCompactDate, it is padded to three digits so the result can
+be read back.
+
+Example:
+
+	t := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	convert.FormatROC(t, convert.ROCLongDate)    // "民國113年5月20日"
+	convert.FormatROC(t, convert.ROCSlashDate)   // "113/05/20"
+	convert.FormatROC(t, convert.ROCCompactDate) // "1130520"
+	convert.FormatROC(t, "民國2006年")              // "民國113年"
+*/
+func FormatROC(t time.Time, layout string) string {
+	parts := strings.Split(layout, "2006")
+	var b strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			b.WriteString(formatROCYear(ROCYear(t.Year()), isCompactYear(part)))
+		}
+		b.WriteString(t.Format(part))
+	}
+	return b.String()
+}
+
+func formatROCYear(year int, pad bool) string {
+	prefix := ""
+	if year < 0 {
+		prefix, year = "前", -year
+	}
+	if pad {
+		return fmt.Sprintf("%s%03d", prefix, year)
+	}
+	return prefix + strconv.Itoa(year)
+}
+
+// isCompactYear reports whether the layout after the year starts with a
+// number, leaving no separator to find the end of the year.
+func isCompactYear(rest string) bool {
+	return rest != "" && rest[0] >= '0' && rest[0] <= '9'
+}
+
+/*
+ParseROC parses a date formatted with FormatROC and the same layout. The
+year must appear once in layout, after nothing but literal text such as
+"民國". It may be preceded by 前 for years before 1912 and, as in FormatROC,
+has exactly three digits when directly followed by another number. The
+result is in UTC, as with time.Parse.
+
+Errors are *ConversionError, wrapping ErrSyntax for a malformed year,
+ErrOverflow for a year outside 1 to 9999 AD or the *time.ParseError for the
+rest of the date.
+
+Example:
+
+	t, _ := convert.ParseROC(convert.ROCLongDate, "民國113年5月20日") // 2024-05-20
+	t, _ = convert.ParseROC(convert.ROCCompactDate, "0990101")   // 2010-01-01
+	t, _ = convert.ParseROC("民國2006年", "民國前1年")                  // 1911-01-01
+*/
+func ParseROC(layout, value string) (time.Time, error) {
+	t, err := parseROC(layout, value)
+	if err != nil {
+		return time.Time{}, &ConversionError{Value: value, Target: reflect.TypeFor[time.Time](), Err: err}
+	}
+	return t, nil
+}
+
+func parseROC(layout, value string) (time.Time, error) {
+	i := strings.Index(layout, "2006")
+	if i < 0 || !strings.HasPrefix(value, layout[:i]) {
+		return time.Time{}, ErrSyntax
+	}
+	prefix, rest := layout[:i], value[i:]
+	before := strings.HasPrefix(rest, "前")
+	rest = strings.TrimPrefix(rest, "前")
+	n := 0
+	for n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+		n++
+	}
+	if isCompactYear(layout[i+len("2006"):]) {
+		n = min(n, 3)
+	}
+	if n == 0 {
+		return time.Time{}, ErrSyntax
+	}
+	if n > 4 {
+		return time.Time{}, ErrOverflow
+	}
+	roc, _ := strconv.Atoi(rest[:n])
+	if roc == 0 {
+		return time.Time{}, ErrSyntax
+	}
+	if before {
+		roc = -roc
+	}
+	year, _ := GregorianYear(roc)
+	if year < 1 || year > 9999 {
+		return time.Time{}, ErrOverflow
+	}
+	return time.Parse(layout, fmt.Sprintf("%s%04d%s", prefix, year, rest[n:]))
+}
+
+// taiwanIDLetters maps the first letter of a Taiwan ID, A to Z, to the
+// two-digit code used in its checksum.
+var taiwanIDLetters = [26]int{
+	10, 11, 12, 13, 14, 15, 16, 17, 34, 18, 19, 20, 21,
+	22, 35, 23, 24, 25, 26, 27, 28, 29, 32, 30, 31, 33,
+}
+
+/*
+ValidateTaiwanID checks a Taiwan national ID number (身分證字號), such as
+A123456789: a letter for the place of registration, 1 or 2 for the sex, seven
+digits and a check digit. Resident certificate numbers for foreign nationals
+(居留證) are accepted in both the current form, with 8 or 9 as the second
+character, and the form issued before 2021, with A to D. Letters may be in
+either case.
+
+It returns nil for a valid number, ErrSyntax for a malformed one and
+ErrChecksum when the check digit is wrong.
+*/
+func ValidateTaiwanID(id string) error {
+	if len(id) != 10 {
+		return ErrSyntax
+	}
+	first, second := upperASCII(id[0]), upperASCII(id[1])
+	if first < 'A' || first > 'Z' {
+		return ErrSyntax
+	}
+	code := taiwanIDLetters[first-'A']
+	sum := code/10 + code%10*9
+	switch {
+	case second == '1' || second == '2' || second == '8' || second == '9':
+		sum += int(second-'0') * 8
+	case second >= 'A' && second <= 'D':
+		sum += taiwanIDLetters[second-'A'] % 10 * 8
+	default:
+		return ErrSyntax
+	}
+	for i := 2; i < 10; i++ {
+		c := id[i]
+		if c < '0' || c > '9' {
+			return ErrSyntax
+		}
+		sum += int(c-'0') * max(9-i, 1)
+	}
+	if sum%10 != 0 {
+		return ErrChecksum
+	}
+	return nil
+}
+
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+/*
+GenerateTaiwanID returns a random national ID number with a valid check
+digit, for test fixtures. The number may belong to a real person, so never
+use it as anything but test data.
+
+Example:
+
+	id, _ := convert.GenerateTaiwanID() // e.g. "F231563702"
+*/
+func GenerateTaiwanID() (string, error) {
+	letter, err := random.StringWithCharset(1, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	if err != nil {
+		return "", err
+	}
+	sex, err := random.StringWithCharset(1, "12")
+	if err != nil {
+		return "", err
+	}
+	digits, err := random.StringWithCharset(7, random.Numeric)
+	if err != nil {
+		return "", err
+	}
+	id := letter + sex + digits
+	for c := byte('0'); c <= '9'; c++ {
+		if ValidateTaiwanID(id+string(c)) == nil {
+			return id + string(c), nil
+		}
+	}
+	panic("unreachable")
+}
+
+// businessNumberWeights are the weights of the eight digits of a unified
+// business number.
+var businessNumberWeights = [8]int{1, 2, 1, 2, 1, 2, 4, 1}
+
+/*
+ValidateBusinessNumber checks a Taiwan unified business number (統一編號),
+the eight-digit number of a company or organization. Each digit is multiplied
+by its weight, 1, 2, 1, 2, 1, 2, 4, 1, and the digits of the products are
+added up. The number is valid when the sum is divisible by 5 or, when the
+seventh digit is 7, the sum plus one is. This is the rule in force since
+2023; every number valid under the older divisible-by-10 rule is also valid
+under it.
+
+It returns nil for a valid number, ErrSyntax for a malformed one and
+ErrChecksum when the check digit is wrong.
+*/
+func ValidateBusinessNumber(s string) error {
+	if len(s) != 8 {
+		return ErrSyntax
+	}
+	sum := 0
+	for i := range 8 {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return ErrSyntax
+		}
+		p := int(c-'0') * businessNumberWeights[i]
+		sum += p/10 + p%10
+	}
+	if sum%5 == 0 || (s[6] == '7' && (sum+1)%5 == 0) {
+		return nil
+	}
+	return ErrChecksum
+}
+
+/*
+GenerateBusinessNumber returns a random unified business number with a
+valid check digit, for test fixtures. The number may belong to a real
+company, so never use it as anything but test data.
+
+Example:
+
+	n, _ := convert.GenerateBusinessNumber() // e.g. "04595257"
+*/
+func GenerateBusinessNumber() (string, error) {
+	digits, err := random.StringWithCharset(7, random.Numeric)
+	if err != nil {
+		return "", err
+	}
+	for c := byte('0'); c <= '9'; c++ {
+		if ValidateBusinessNumber(digits+string(c)) == nil {
+			return digits + string(c), nil
+		}
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,188 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestROCYear(t *testing.T) {
+	tests := []struct {
+		year, roc int
+	}{
+		{2024, 113},
+		{1912, 1},
+		{1911, -1},
+		{1900, -12},
+	}
+	for _, tt := range tests {
+		if got := ROCYear(tt.year); got != tt.roc {
+			t.Errorf("ROCYear(%d) = %d, want %d", tt.year, got, tt.roc)
+		}
+		if got, err := GregorianYear(tt.roc); err != nil || got != tt.year {
+			t.Errorf("GregorianYear(%d) = %d, %v, want %d", tt.roc, got, err, tt.year)
+		}
+	}
+	if _, err := GregorianYear(0); !errors.Is(err, ErrOverflow) {
+		t.Errorf("GregorianYear(0) error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestFormatROC(t *testing.T) {
+	tests := []struct {
+		t      time.Time
+		layout string
+		want   string
+	}{
+		{time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), ROCLongDate, "民國113年5月20日"},
+		{time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), ROCSlashDate, "113/05/20"},
+		{time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), ROCCompactDate, "1130520"},
+		{time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), ROCCompactDate, "0990101"},
+		{time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC), ROCSlashDate, "99/01/01"},
+		{time.Date(1911, 10, 10, 0, 0, 0, 0, time.UTC), "民國2006年", "民國前1年"},
+		{time.Date(2024, 5, 20, 15, 4, 0, 0, time.UTC), "2006-01-02 15:04", "113-05-20 15:04"},
+	}
+	for _, tt := range tests {
+		if got := FormatROC(tt.t, tt.layout); got != tt.want {
+			t.Errorf("FormatROC(%v, %q) = %q, want %q", tt.t, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestParseROC(t *testing.T) {
+	tests := []struct {
+		layout, in string
+		want       time.Time
+	}{
+		{ROCLongDate, "民國113年5月20日", time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)},
+		{ROCSlashDate, "113/05/20", time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)},
+		{ROCSlashDate, "99/01/01", time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ROCCompactDate, "1130520", time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)},
+		{ROCCompactDate, "0990101", time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"民國2006年", "民國前1年", time.Date(1911, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2006-01-02 15:04", "113-05-20 15:04", time.Date(2024, 5, 20, 15, 4, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseROC(tt.layout, tt.in)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseROC(%q, %q) = %v, %v, want %v", tt.layout, tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		layout, in string
+		err        error
+	}{
+		{ROCLongDate, "中華民國113年5月20日", ErrSyntax},
+		{ROCLongDate, "民國年5月20日", ErrSyntax},
+		{ROCLongDate, "民國0年5月20日", ErrSyntax},
+		{"01/02", "05/20", ErrSyntax},
+		{ROCSlashDate, "10000/01/01", ErrOverflow},
+		{ROCSlashDate, "8089/01/01", ErrOverflow},
+		{ROCSlashDate, "前1912/01/01", ErrOverflow},
+	} {
+		_, err := ParseROC(tt.layout, tt.in)
+		var ce *ConversionError
+		if !errors.As(err, &ce) || !errors.Is(err, tt.err) {
+			t.Errorf("ParseROC(%q, %q) error = %v, want %v", tt.layout, tt.in, err, tt.err)
+		}
+	}
+
+	var pe *time.ParseError
+	if _, err := ParseROC(ROCLongDate, "民國113年13月20日"); !errors.As(err, &pe) {
+		t.Errorf("ParseROC(month 13) error = %v, want *time.ParseError", err)
+	}
+}
+
+func TestROCRoundTrip(t *testing.T) {
+	for _, d := range []time.Time{
+		time.Date(1912, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1949, 12, 7, 0, 0, 0, 0, time.UTC),
+		time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(1899, 6, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		for _, layout := range []string{ROCLongDate, ROCSlashDate, ROCCompactDate} {
+			s := FormatROC(d, layout)
+			if got, err := ParseROC(layout, s); err != nil || !got.Equal(d) {
+				t.Errorf("ParseROC(%q, %q) = %v, %v, want %v", layout, s, got, err, d)
+			}
+		}
+	}
+}
+
+func TestValidateTaiwanID(t *testing.T) {
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{"A123456789", nil},
+		{"a123456789", nil},
+		{"F131104093", nil},
+		{"A800000014", nil},
+		{"AC01234567", nil},
+		{"A123456788", ErrChecksum},
+		{"A223456789", ErrChecksum},
+		{"A12345678", ErrSyntax},
+		{"A1234567890", ErrSyntax},
+		{"1123456789", ErrSyntax},
+		{"A323456789", ErrSyntax},
+		{"AE23456789", ErrSyntax},
+		{"A12345678X", ErrSyntax},
+		{"", ErrSyntax},
+	}
+	for _, tt := range tests {
+		if err := ValidateTaiwanID(tt.in); !errors.Is(err, tt.err) {
+			t.Errorf("ValidateTaiwanID(%q) = %v, want %v", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestValidateBusinessNumber(t *testing.T) {
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{"04595257", nil},
+		{"22099131", nil},
+		// Valid only under the divisible-by-5 rule.
+		{"10458570", nil},
+		// Seventh digit 7, valid only with the sum plus one.
+		{"10458574", nil},
+		{"22099132", ErrChecksum},
+		{"10458573", ErrChecksum},
+		{"0459525", ErrSyntax},
+		{"045952570", ErrSyntax},
+		{"0459525a", ErrSyntax},
+		{"", ErrSyntax},
+	}
+	for _, tt := range tests {
+		if err := ValidateBusinessNumber(tt.in); !errors.Is(err, tt.err) {
+			t.Errorf("ValidateBusinessNumber(%q) = %v, want %v", tt.in, err, tt.err)
+		}
+	}
+}
+
+func TestGenerateTaiwanID(t *testing.T) {
+	for range 100 {
+		id, err := GenerateTaiwanID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateTaiwanID(id); err != nil || (id[1] != '1' && id[1] != '2') {
+			t.Errorf("GenerateTaiwanID() = %q, invalid: %v", id, err)
+		}
+	}
+}
+
+func TestGenerateBusinessNumber(t *testing.T) {
+	for range 100 {
+		n, err := GenerateBusinessNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateBusinessNumber(n); err != nil {
+			t.Errorf("GenerateBusinessNumber() = %q, invalid: %v", n, err)
+		}
+	}
+}
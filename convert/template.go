@@ -0,0 +1,114 @@
+package convert
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/appleboy/com/file"
+)
+
+/*
+FuncMap returns the package's converters for use in text/template and
+html/template. Each call returns a new map, which can be passed to Funcs of
+either package. Functions that can fail return the error as a second
+result, which stops template execution.
+
+	toString         ToString
+	toBool           ParseBool with the words of BoolStrict and BoolYAML,
+	                 so 1/0, true/false, yes/no and on/off; others fail
+	toInt            To[int]; fails instead of clamping like ToInt
+	toInt64          To[int64]
+	toFloat64        To[float64]
+	toSnake          ToSnake, and likewise toScreamingSnake, toKebab,
+	                 toDot, toCamel, toPascal and toTitleWords
+	slugify          Slugify
+	formatSize       file.FormatSize of any integer value
+	formatNumber     FormatNumber
+	formatDuration   FormatDuration
+	hash             the hex digest of a string, such as hash "sha256" .Body;
+	                 names are those of HashAlgo.String, in any case and
+	                 with or without the dashes
+	fromPtr          the value a pointer points to, or the zero value for
+	                 a nil pointer; other values are returned as they are
+
+Example:
+
+	t := template.Must(template.New("").Funcs(convert.FuncMap()).Parse(
+		`{{ .Name | toSnake }} {{ formatSize .Size }} {{ hash "md5" .Name }}`))
+*/
+func FuncMap() map[string]any {
+	return map[string]any{
+		"toString":         ToString,
+		"toBool":           templateBool,
+		"toInt":            To[int],
+		"toInt64":          To[int64],
+		"toFloat64":        To[float64],
+		"toSnake":          ToSnake,
+		"toScreamingSnake": ToScreamingSnake,
+		"toKebab":          ToKebab,
+		"toDot":            ToDot,
+		"toCamel":          ToCamel,
+		"toPascal":         ToPascal,
+		"toTitleWords":     ToTitleWords,
+		"slugify":          Slugify,
+		"formatSize":       templateFormatSize,
+		"formatNumber":     FormatNumber,
+		"formatDuration":   FormatDuration,
+		"hash":             templateHash,
+		"fromPtr":          templateFromPtr,
+	}
+}
+
+// templateBools accepts the words of both BoolStrict and BoolYAML.
+var templateBools = mustBoolProfile(
+	[]string{"1", "t", "true", "y", "yes", "on"},
+	[]string{"0", "f", "false", "n", "no", "off"},
+)
+
+func templateBool(v any) (bool, error) {
+	return Converter{Bool: templateBools}.ParseBool(v)
+}
+
+func templateFormatSize(size any) (string, error) {
+	n, err := To[int64](size)
+	if err != nil {
+		return "", err
+	}
+	return file.FormatSize(n), nil
+}
+
+func templateHash(algo, s string) (string, error) {
+	a, ok := hashAlgoByName(algo)
+	if !ok {
+		return "", ErrUnknownHash
+	}
+	d, err := HashString(a, s)
+	if err != nil {
+		return "", err
+	}
+	return d.Hex(), nil
+}
+
+// hashAlgoByName finds an algorithm by its String name, ignoring case and
+// dashes, so "sha256", "SHA-256" and "Sha256" all match SHA256.
+func hashAlgoByName(name string) (HashAlgo, bool) {
+	key := strings.ReplaceAll(name, "-", "")
+	for a := HashAlgo(1); a.Available(); a++ {
+		if strings.EqualFold(strings.ReplaceAll(a.String(), "-", ""), key) {
+			return a, true
+		}
+	}
+	return 0, false
+}
+
+// templateFromPtr is FromPtr for values of any type.
+func templateFromPtr(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return v
+	}
+	if rv.IsNil() {
+		return reflect.Zero(rv.Type().Elem()).Interface()
+	}
+	return rv.Elem().Interface()
+}
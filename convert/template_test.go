@@ -0,0 +1,116 @@
+package convert
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestFuncMapTextTemplate(t *testing.T) {
+	name := "Ada"
+	data := map[string]any{
+		"Field":   "HTTPServerID",
+		"Size":    int64(1536),
+		"Count":   "42",
+		"Enabled": "yes",
+		"Price":   1234.5,
+		"Elapsed": 90 * time.Minute,
+		"Name":    &name,
+		"Nil":     (*int)(nil),
+		"Title":   "Crème Brûlée!",
+	}
+	tests := []struct {
+		tmpl, want string
+	}{
+		{`{{ .Field | toSnake }}`, "http_server_id"},
+		{`{{ .Field | toKebab }}`, "http-server-id"},
+		{`{{ "http_server_id" | toPascal }}`, "HTTPServerID"},
+		{`{{ "http_server_id" | toCamel }}`, "httpServerID"},
+		{`{{ .Field | toScreamingSnake }}`, "HTTP_SERVER_ID"},
+		{`{{ .Field | toDot }}`, "http.server.id"},
+		{`{{ .Field | toTitleWords }}`, "HTTP Server ID"},
+		{`{{ .Title | slugify }}`, "creme-brulee"},
+		{`{{ formatSize .Size }}`, "1.5 KB"},
+		{`{{ formatSize 512 }}`, "512 B"},
+		{`{{ formatNumber .Price 2 }}`, "1,234.50"},
+		{`{{ formatDuration .Elapsed }}`, "1h 30m"},
+		{`{{ add1 (toInt .Count) }}`, "43"},
+		{`{{ toInt64 .Count }}`, "42"},
+		{`{{ toFloat64 "0.5" }}`, "0.5"},
+		{`{{ if toBool .Enabled }}on{{ end }}`, "on"},
+		{`{{ if toBool "no" }}on{{ else }}off{{ end }}`, "off"},
+		{`{{ if toBool "OFF" }}on{{ else }}off{{ end }}`, "off"},
+		{`{{ if toBool "0" }}on{{ else }}off{{ end }}`, "off"},
+		{`{{ if toBool true }}on{{ end }}`, "on"},
+		{`{{ toString 3.25 }}`, "3.25"},
+		{`{{ hash "sha256" "hello" }}`, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{`{{ hash "MD5" "hello" }}`, "5d41402abc4b2a76b9719d911017c592"},
+		{`{{ fromPtr .Name }}`, "Ada"},
+		{`{{ fromPtr .Nil }}`, "0"},
+		{`{{ fromPtr .Count }}`, "42"},
+	}
+	for _, tt := range tests {
+		tmpl := template.Must(template.New("").Funcs(FuncMap()).Funcs(template.FuncMap{
+			"add1": func(n int) int { return n + 1 },
+		}).Parse(tt.tmpl))
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			t.Errorf("%s: %v", tt.tmpl, err)
+			continue
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s = %q, want %q", tt.tmpl, b.String(), tt.want)
+		}
+	}
+}
+
+func TestFuncMapErrors(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		err  error
+	}{
+		{`{{ toInt "abc" }}`, ErrSyntax},
+		{`{{ toBool "maybe" }}`, ErrSyntax},
+		{`{{ toInt64 "9223372036854775808" }}`, ErrOverflow},
+		{`{{ toInt 1.5 }}`, ErrTruncated},
+		{`{{ formatSize "big" }}`, ErrSyntax},
+		{`{{ hash "sha0" "x" }}`, ErrUnknownHash},
+	}
+	for _, tt := range tests {
+		tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(tt.tmpl))
+		err := tmpl.Execute(&strings.Builder{}, nil)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s error = %v, want %v", tt.tmpl, err, tt.err)
+		}
+	}
+}
+
+func TestFuncMapHTMLTemplate(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap()).Parse(
+		`<a href="/posts/{{ .Title | slugify }}" title="{{ fromPtr .Title }}">{{ formatSize .Size }}</a>`))
+	title := `Tom & Jerry <script>`
+	var b strings.Builder
+	err := tmpl.Execute(&b, map[string]any{"Title": &title, "Size": 2048})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<a href="/posts/tom-jerry-script" title="Tom &amp; Jerry &lt;script&gt;">2.0 KB</a>`
+	if b.String() != want {
+		t.Errorf("got  %s\nwant %s", b.String(), want)
+	}
+
+	if err := tmpl.Execute(&strings.Builder{}, map[string]any{"Title": &title, "Size": -1.5}); !errors.Is(err, ErrTruncated) {
+		t.Errorf("html/template error = %v, want ErrTruncated", err)
+	}
+}
+
+func TestFuncMapIsFresh(t *testing.T) {
+	m := FuncMap()
+	delete(m, "toString")
+	if _, ok := FuncMap()["toString"]; !ok {
+		t.Error("FuncMap returned a shared map")
+	}
+}
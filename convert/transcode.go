@@ -0,0 +1,258 @@
+package convert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/appleboy/com/bytesconv"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+var (
+	// ErrInvalidSequence reports input bytes that are not valid in the
+	// source encoding.
+	ErrInvalidSequence = errors.New("convert: invalid byte sequence")
+	// ErrUnrepresentable reports a character that the target encoding
+	// cannot represent.
+	ErrUnrepresentable = errors.New("convert: character not representable in target encoding")
+)
+
+// InvalidSequenceError records where Transcode failed. Err is
+// ErrInvalidSequence when the input is not valid in Encoding, or
+// ErrUnrepresentable when Encoding, the target, has no mapping for the
+// character. Offset is always a byte offset into the input.
+type InvalidSequenceError struct {
+	Encoding bytesconv.Encoding
+	Offset   int
+	Err      error
+}
+
+func (e *InvalidSequenceError) Error() string {
+	if errors.Is(e.Err, ErrUnrepresentable) {
+		return fmt.Sprintf("convert: character at byte %d cannot be encoded in %s", e.Offset, e.Encoding)
+	}
+	return fmt.Sprintf("convert: invalid %s sequence at byte %d", e.Encoding, e.Offset)
+}
+
+func (e *InvalidSequenceError) Unwrap() error { return e.Err }
+
+/*
+Transcoder converts text between two encodings. The zero Replace fails on
+the first invalid or unrepresentable character with an
+*InvalidSequenceError.
+
+Example:
+
+	t := convert.Transcoder{From: bytesconv.UTF8, To: bytesconv.Big5, Replace: true, Replacement: "?"}
+	s, _ := t.String("中文😀") // "\xa4\xa4\xa4\xe5?"
+*/
+type Transcoder struct {
+	From, To bytesconv.Encoding
+	// Replace substitutes Replacement for invalid input and for characters
+	// To cannot represent, instead of failing.
+	Replace bool
+	// Replacement defaults to U+FFFD. If To cannot represent it, '?' is
+	// written instead.
+	Replacement string
+}
+
+// decodeChunk is the largest window the careful decoding path uses.
+const decodeChunk = 32 << 10
+
+// String transcodes s.
+func (t *Transcoder) String(s string) (string, error) {
+	out, err := t.transcode(bytesconv.StrToBytes(s))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Bytes transcodes b into a new slice.
+func (t *Transcoder) Bytes(b []byte) ([]byte, error) {
+	out, err := t.transcode(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > 0 && len(b) > 0 && &out[0] == &b[0] {
+		out = bytes.Clone(out)
+	}
+	return out, nil
+}
+
+// transcode may return src itself when no conversion is needed.
+func (t *Transcoder) transcode(src []byte) ([]byte, error) {
+	from, err := t.From.TextEncoding()
+	if err != nil {
+		return nil, err
+	}
+	to, err := t.To.TextEncoding()
+	if err != nil {
+		return nil, err
+	}
+	var text []byte
+	if t.From == bytesconv.UTF8 {
+		text, err = t.validateUTF8(src)
+	} else {
+		text, err = t.decode(src, from)
+	}
+	if err != nil || t.To == bytesconv.UTF8 {
+		return text, err
+	}
+	return t.encode(text, to, src, from)
+}
+
+func (t *Transcoder) replacement() string {
+	if t.Replacement != "" {
+		return t.Replacement
+	}
+	return string(utf8.RuneError)
+}
+
+// validateUTF8 checks UTF-8 input, replacing each invalid byte if t.Replace
+// is set.
+func (t *Transcoder) validateUTF8(src []byte) ([]byte, error) {
+	if utf8.Valid(src) {
+		return src, nil
+	}
+	out := make([]byte, 0, len(src)+len(src)/4)
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRune(src[i:])
+		if r == utf8.RuneError && size == 1 {
+			if !t.Replace {
+				return nil, &InvalidSequenceError{Encoding: t.From, Offset: i, Err: ErrInvalidSequence}
+			}
+			out = append(out, t.replacement()...)
+		} else {
+			out = append(out, src[i:i+size]...)
+		}
+		i += size
+	}
+	return out, nil
+}
+
+// decode converts src from enc to UTF-8. The x/text decoders silently
+// write U+FFFD for invalid input, so a U+FFFD in the output is traced back
+// to its input bytes to tell a real U+FFFD from an invalid sequence.
+func (t *Transcoder) decode(src []byte, enc encoding.Encoding) ([]byte, error) {
+	out, err := enc.NewDecoder().Bytes(src)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.ContainsRune(out, utf8.RuneError) {
+		return out, nil
+	}
+
+	// Encoded form of a genuine U+FFFD, if the encoding has one.
+	realFFFD, _ := enc.NewEncoder().Bytes([]byte(string(utf8.RuneError)))
+	dec := enc.NewDecoder()
+	res := make([]byte, 0, len(out))
+	buf := make([]byte, decodeChunk)
+	// The window starts small after every U+FFFD, so input with many
+	// invalid bytes is not decoded over and over in large chunks.
+	const minWindow = 32
+	window := minWindow
+	for off := 0; off < len(src); {
+		dec.Reset()
+		nDst, nSrc, err := dec.Transform(buf[:window], src[off:], true)
+		i := bytes.IndexRune(buf[:nDst], utf8.RuneError)
+		if i < 0 {
+			res = append(res, buf[:nDst]...)
+			off += nSrc
+			if err != nil && !errors.Is(err, transform.ErrShortDst) {
+				return nil, err
+			}
+			window = min(2*window, decodeChunk)
+			continue
+		}
+		// Decode only the characters before the U+FFFD.
+		dec.Reset()
+		nDst, nSrc, _ = dec.Transform(buf[:i], src[off:], true)
+		res = append(res, buf[:nDst]...)
+		off += nSrc
+		window = minWindow
+		if len(realFFFD) > 0 && bytes.HasPrefix(src[off:], realFFFD) {
+			res = utf8.AppendRune(res, utf8.RuneError)
+			off += len(realFFFD)
+			continue
+		}
+		if !t.Replace {
+			return nil, &InvalidSequenceError{Encoding: t.From, Offset: off, Err: ErrInvalidSequence}
+		}
+		// Find how many bytes the decoder consumed for the invalid
+		// sequence: the buffer only has room for the U+FFFD it produces.
+		dec.Reset()
+		_, nSrc, _ = dec.Transform(buf[:len(string(utf8.RuneError))], src[off:], true)
+		res = append(res, t.replacement()...)
+		off += max(nSrc, 1)
+	}
+	return res, nil
+}
+
+// encode converts the UTF-8 text to enc. src and from are the original
+// input, used to report offsets into it.
+func (t *Transcoder) encode(text []byte, enc encoding.Encoding, src []byte, from encoding.Encoding) ([]byte, error) {
+	e := enc.NewEncoder()
+	out := make([]byte, 0, len(text))
+	buf := make([]byte, min(len(text)+utf8.UTFMax, decodeChunk))
+	var repl []byte
+	for off := 0; ; {
+		nDst, nSrc, err := e.Transform(buf, text[off:], true)
+		out = append(out, buf[:nDst]...)
+		off += nSrc
+		if err == nil {
+			return out, nil
+		}
+		if errors.Is(err, transform.ErrShortDst) {
+			continue
+		}
+		if !t.Replace {
+			return nil, &InvalidSequenceError{Encoding: t.To, Offset: t.inputOffset(src, from, off), Err: ErrUnrepresentable}
+		}
+		if repl == nil {
+			if repl, err = enc.NewEncoder().Bytes([]byte(t.replacement())); err != nil || len(repl) == 0 {
+				repl = []byte("?")
+			}
+		}
+		out = append(out, repl...)
+		_, size := utf8.DecodeRune(text[off:])
+		off += size
+		e.Reset()
+	}
+}
+
+// inputOffset maps an offset in the decoded UTF-8 text back to the input.
+// It is only called without Replace, when the text is the exact decoding.
+func (t *Transcoder) inputOffset(src []byte, from encoding.Encoding, off int) int {
+	if t.From == bytesconv.UTF8 {
+		return off
+	}
+	// Decoding into a buffer of exactly off bytes stops right before the
+	// character that starts there.
+	_, nSrc, _ := from.NewDecoder().Transform(make([]byte, off), src, true)
+	return nSrc
+}
+
+/*
+Transcode converts s from one encoding to another. It fails on the first
+invalid or unrepresentable character with an *InvalidSequenceError; use a
+Transcoder with Replace to substitute a replacement instead.
+
+Example:
+
+	s, err := convert.Transcode("\xa4\xa4\xa4\xe5", bytesconv.Big5, bytesconv.ShiftJIS)
+*/
+func Transcode(s string, from, to bytesconv.Encoding) (string, error) {
+	t := Transcoder{From: from, To: to}
+	return t.String(s)
+}
+
+// ConvertUTF8ToBig5 converts a UTF-8 string to Big5. Unlike
+// ConvertBig5ToUTF8 it reports failures, such as characters that have no
+// Big5 mapping, as an *InvalidSequenceError.
+func ConvertUTF8ToBig5(s string) (string, error) {
+	return Transcode(s, bytesconv.UTF8, bytesconv.Big5)
+}
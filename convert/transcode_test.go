@@ -0,0 +1,204 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/appleboy/com/bytesconv"
+)
+
+var transcodeSamples = map[bytesconv.Encoding]string{
+	bytesconv.Big5:     "中文測試，繁體字",
+	bytesconv.GBK:      "中文测试，简体字",
+	bytesconv.GB18030:  "中文测试，简体字😀",
+	bytesconv.ShiftJIS: "日本語のテキスト",
+	bytesconv.EUCJP:    "日本語のテキスト",
+	bytesconv.EUCKR:    "한국어 텍스트",
+	bytesconv.UTF16LE:  "mixed 中文 😀",
+	bytesconv.UTF16BE:  "mixed 中文 😀",
+}
+
+func TestTranscodeRoundTrip(t *testing.T) {
+	for enc, text := range transcodeSamples {
+		t.Run(enc.String(), func(t *testing.T) {
+			encoded, err := Transcode(text, bytesconv.UTF8, enc)
+			if err != nil {
+				t.Fatalf("Transcode(UTF-8 -> %s) error = %v", enc, err)
+			}
+			if encoded == text {
+				t.Fatalf("Transcode(UTF-8 -> %s) returned the input unchanged", enc)
+			}
+			decoded, err := Transcode(encoded, enc, bytesconv.UTF8)
+			if err != nil || decoded != text {
+				t.Errorf("Transcode(%s -> UTF-8) = %q, %v, want %q", enc, decoded, err, text)
+			}
+		})
+	}
+}
+
+func TestTranscodeBetweenLegacyEncodings(t *testing.T) {
+	big5, err := ConvertUTF8ToBig5("中文")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if big5 != "\xa4\xa4\xa4\xe5" {
+		t.Fatalf("ConvertUTF8ToBig5() = %q", big5)
+	}
+	sjis, err := Transcode(big5, bytesconv.Big5, bytesconv.ShiftJIS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := Transcode(sjis, bytesconv.ShiftJIS, bytesconv.UTF8)
+	if err != nil || back != "中文" {
+		t.Errorf("Shift_JIS round trip = %q, %v", back, err)
+	}
+	if got := ConvertBig5ToUTF8(big5); got != "中文" {
+		t.Errorf("ConvertBig5ToUTF8() = %q", got)
+	}
+}
+
+func TestTranscodeInvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		from   bytesconv.Encoding
+		offset int
+	}{
+		{"utf-8", "ab\xffcd", bytesconv.UTF8, 2},
+		{"big5 bad trail", "ab\xa4\x20cd", bytesconv.Big5, 2},
+		{"big5 after text", "\xa4\xa4\xa4\xe5\xff", bytesconv.Big5, 4},
+		{"big5 truncated", "\xa4\xa4\xa4", bytesconv.Big5, 2},
+		{"shift_jis", "\x82\xa0\x85\x40", bytesconv.ShiftJIS, 2},
+		{"utf-16le lone surrogate", "a\x00\x00\xd8b\x00", bytesconv.UTF16LE, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Transcode(tt.in, tt.from, bytesconv.UTF8)
+			var ise *InvalidSequenceError
+			if !errors.As(err, &ise) {
+				t.Fatalf("Transcode() error = %v, want *InvalidSequenceError", err)
+			}
+			if !errors.Is(err, ErrInvalidSequence) || ise.Encoding != tt.from || ise.Offset != tt.offset {
+				t.Errorf("Transcode() error = %+v, want %s at %d", ise, tt.from, tt.offset)
+			}
+		})
+	}
+}
+
+func TestTranscodeGenuineReplacementChar(t *testing.T) {
+	// GB18030 and UTF-16 can encode U+FFFD itself, which is not an error.
+	for _, enc := range []bytesconv.Encoding{bytesconv.GB18030, bytesconv.UTF16BE} {
+		encoded, err := Transcode("a�b", bytesconv.UTF8, enc)
+		if err != nil {
+			t.Fatalf("Transcode(UTF-8 -> %s) error = %v", enc, err)
+		}
+		got, err := Transcode(encoded, enc, bytesconv.UTF8)
+		if err != nil || got != "a�b" {
+			t.Errorf("Transcode(%s -> UTF-8) = %q, %v", enc, got, err)
+		}
+	}
+	if got, err := Transcode("a�b", bytesconv.UTF8, bytesconv.UTF8); err != nil || got != "a�b" {
+		t.Errorf("Transcode(UTF-8 -> UTF-8) = %q, %v", got, err)
+	}
+}
+
+func TestTranscodeUnrepresentable(t *testing.T) {
+	_, err := ConvertUTF8ToBig5("中文😀")
+	var ise *InvalidSequenceError
+	if !errors.As(err, &ise) || !errors.Is(err, ErrUnrepresentable) {
+		t.Fatalf("ConvertUTF8ToBig5() error = %v, want ErrUnrepresentable", err)
+	}
+	if ise.Encoding != bytesconv.Big5 || ise.Offset != 6 {
+		t.Errorf("error = %+v, want Big5 at 6", ise)
+	}
+
+	// The offset refers to the Shift_JIS input, not to the intermediate
+	// UTF-8: "日" is two bytes in Shift_JIS and three in UTF-8, and the
+	// half-width "ｱ" has no EUC-KR mapping.
+	_, err = Transcode("ab\x93\xfa\xb1", bytesconv.ShiftJIS, bytesconv.EUCKR)
+	if !errors.As(err, &ise) || !errors.Is(err, ErrUnrepresentable) {
+		t.Fatalf("Transcode() error = %v, want ErrUnrepresentable", err)
+	}
+	if ise.Encoding != bytesconv.EUCKR || ise.Offset != 4 {
+		t.Errorf("error = %+v, want EUC-KR at 4", ise)
+	}
+	if want := "convert: character at byte 4 cannot be encoded in EUC-KR"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestTranscoderReplace(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   Transcoder
+		in   string
+		want string
+	}{
+		{
+			"unrepresentable default",
+			Transcoder{From: bytesconv.UTF8, To: bytesconv.Big5, Replace: true},
+			"中😀文", "\xa4\xa4?\xa4\xe5",
+		},
+		{
+			"unrepresentable custom",
+			Transcoder{From: bytesconv.UTF8, To: bytesconv.Big5, Replace: true, Replacement: "□"},
+			"中😀", "\xa4\xa4\xa1\xbc",
+		},
+		{
+			"invalid utf-8",
+			Transcoder{From: bytesconv.UTF8, To: bytesconv.UTF8, Replace: true},
+			"a\xffb", "a�b",
+		},
+		{
+			"invalid big5",
+			Transcoder{From: bytesconv.Big5, To: bytesconv.UTF8, Replace: true, Replacement: "*"},
+			"\xa4\xa4\xff\xa4\xe5", "中*文",
+		},
+		{
+			"invalid big5 to shift_jis",
+			Transcoder{From: bytesconv.Big5, To: bytesconv.ShiftJIS, Replace: true},
+			"a\xffb", "a?b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tr.String(tt.in)
+			if err != nil || got != tt.want {
+				t.Errorf("String(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+			}
+			b, err := tt.tr.Bytes([]byte(tt.in))
+			if err != nil || string(b) != tt.want {
+				t.Errorf("Bytes(%q) = %q, %v, want %q", tt.in, b, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranscoderBytesDoesNotAlias(t *testing.T) {
+	in := []byte("plain")
+	tr := Transcoder{From: bytesconv.UTF8, To: bytesconv.UTF8}
+	out, err := tr.Bytes(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out[0] = 'P'
+	if string(in) != "plain" {
+		t.Errorf("Bytes() result aliases its input")
+	}
+}
+
+func TestTranscodeUnknownEncoding(t *testing.T) {
+	if _, err := Transcode("x", bytesconv.Unknown, bytesconv.UTF8); !errors.Is(err, bytesconv.ErrUnknownEncoding) {
+		t.Errorf("Transcode() error = %v, want ErrUnknownEncoding", err)
+	}
+	if _, err := Transcode("x", bytesconv.UTF8, bytesconv.Encoding(99)); !errors.Is(err, bytesconv.ErrUnknownEncoding) {
+		t.Errorf("Transcode() error = %v, want ErrUnknownEncoding", err)
+	}
+}
+
+func BenchmarkTranscode(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = Transcode(big5Text, bytesconv.Big5, bytesconv.UTF8)
+	}
+}
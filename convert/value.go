@@ -0,0 +1,372 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is reported by Value getters when the path passed to Get does
+// not lead to a value.
+var ErrNotFound = errors.New("convert: path not found")
+
+/*
+Value wraps dynamic data, such as decoded JSON, YAML or a map[string]any
+config, for path lookups and typed access. Values are immutable and cheap to
+copy. A lookup that fails yields a Value whose getters return the error, so
+lookups can be chained and checked once; the Or getters return their default
+instead.
+
+Numbers are never clamped or rounded: a json.Number is read as int64,
+uint64 or float64, whichever holds it exactly, and every getter follows the
+rules of To, failing with ErrOverflow or ErrTruncated instead of guessing.
+Decode JSON with ParseJSON, which keeps numbers as json.Number, to read
+integers beyond 2^53 without loss.
+
+Example:
+
+	v, _ := convert.ParseJSON([]byte(`{"a": {"b": [1, 2, {"c": 9007199254740993}]}}`))
+	n, _ := v.Get("a.b[2].c").Int64()        // 9007199254740993
+	port := v.Get("server.port").IntOr(8080) // 8080
+*/
+type Value struct {
+	v    any
+	path string
+	err  error
+}
+
+// ValueOf wraps v. A nil v is a JSON null.
+func ValueOf(v any) Value {
+	return Value{v: v}
+}
+
+// ParseJSON decodes a single JSON document into a Value, keeping numbers as
+// json.Number. Trailing data after the document is an error wrapping
+// ErrSyntax.
+func ParseJSON(data []byte) (Value, error) {
+	var v Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return Value{}, err
+	}
+	return v, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler like ParseJSON, so a Value can
+// hold a free-form part of a larger document.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var x any
+	if err := dec.Decode(&x); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("%w: data after the JSON value", ErrSyntax)
+	}
+	*v = Value{v: x}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Numbers read by ParseJSON are
+// written back exactly as they appeared.
+func (v Value) MarshalJSON() ([]byte, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return json.Marshal(v.v)
+}
+
+/*
+Get returns the value at path, relative to v. Keys are separated by dots
+and list elements are addressed by index in brackets or as a plain key:
+"a.b[2].c" and "a.b.2.c" are the same. Keys that contain dots or brackets
+can be quoted in brackets, as in `a["x.y"]`. An empty path returns v.
+
+Maps with string keys, slices and arrays are traversed, including through
+pointers. If path does not lead to a value, the result reports a
+*FieldError wrapping ErrNotFound, or ErrSyntax for a malformed path.
+*/
+func (v Value) Get(path string) Value {
+	if v.err != nil || path == "" {
+		return v
+	}
+	full := joinValuePath(v.path, path)
+	cur := v.v
+	for rest := path; rest != ""; {
+		var key string
+		var ok bool
+		key, rest, ok = nextPathKey(rest)
+		if !ok {
+			return Value{path: full, err: &FieldError{Path: full, Err: ErrSyntax}}
+		}
+		if cur, ok = valueChild(cur, key); !ok {
+			return Value{path: full, err: &FieldError{Path: full, Err: ErrNotFound}}
+		}
+	}
+	return Value{v: cur, path: full}
+}
+
+// Key returns the value of key in a map, without parsing key as a path.
+func (v Value) Key(key string) Value {
+	return v.Get(`[` + strconv.Quote(key) + `]`)
+}
+
+// Index returns element i of a list.
+func (v Value) Index(i int) Value {
+	return v.Get("[" + strconv.Itoa(i) + "]")
+}
+
+func joinValuePath(base, path string) string {
+	if strings.HasPrefix(path, "[") {
+		return base + path
+	}
+	return joinPath(base, path)
+}
+
+// nextPathKey splits the first key off path: a name up to the next dot or
+// bracket, a bracketed index or a bracketed quoted string.
+func nextPathKey(path string) (key, rest string, ok bool) {
+	switch {
+	case strings.HasPrefix(path, `["`):
+		q, err := strconv.QuotedPrefix(path[1:])
+		if err != nil {
+			return "", "", false
+		}
+		key, _ = strconv.Unquote(q)
+		rest, ok = strings.CutPrefix(path[1+len(q):], "]")
+		if !ok {
+			return "", "", false
+		}
+	case strings.HasPrefix(path, "["):
+		end := strings.IndexByte(path, ']')
+		if end < 0 {
+			return "", "", false
+		}
+		key, rest = path[1:end], path[end+1:]
+		if _, err := strconv.ParseUint(key, 10, 0); err != nil {
+			return "", "", false
+		}
+	default:
+		end := strings.IndexAny(path, ".[")
+		if end < 0 {
+			end = len(path)
+		}
+		key, rest = path[:end], path[end:]
+		if key == "" {
+			return "", "", false
+		}
+	}
+	if rest == "" || rest[0] == '[' {
+		return key, rest, true
+	}
+	rest, ok = strings.CutPrefix(rest, ".")
+	if !ok || rest == "" || rest[0] == '[' {
+		return "", "", false
+	}
+	return key, rest, true
+}
+
+// valueChild returns the element key of a map, slice or array.
+func valueChild(cur any, key string) (any, bool) {
+	switch c := cur.(type) {
+	case map[string]any:
+		x, ok := c[key]
+		return x, ok
+	case []any:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(c) {
+			return nil, false
+		}
+		return c[i], true
+	}
+
+	rv := indirectValue(cur)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		x := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+		if !x.IsValid() {
+			return nil, false
+		}
+		return x.Interface(), true
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= rv.Len() {
+			return nil, false
+		}
+		return rv.Index(i).Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+// indirectValue returns the reflect.Value of x with pointers followed.
+func indirectValue(x any) reflect.Value {
+	rv := reflect.ValueOf(x)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// Exists reports whether v holds a value, which may be null.
+func (v Value) Exists() bool { return v.err == nil }
+
+// IsNull reports whether v holds nil, such as a JSON null.
+func (v Value) IsNull() bool { return v.err == nil && v.v == nil }
+
+// Err returns the error of the lookup that produced v, or nil.
+func (v Value) Err() error { return v.err }
+
+// Interface returns the wrapped value, with numbers from ParseJSON still as
+// json.Number.
+func (v Value) Interface() any { return v.v }
+
+// Len returns the number of elements of a list or map, and 0 for anything
+// else.
+func (v Value) Len() int {
+	switch c := v.v.(type) {
+	case map[string]any:
+		return len(c)
+	case []any:
+		return len(c)
+	case nil:
+		return 0
+	}
+	switch rv := indirectValue(v.v); rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return rv.Len()
+	default:
+		return 0
+	}
+}
+
+// Keys returns the sorted keys of a map with string keys, and nil for
+// anything else.
+func (v Value) Keys() []string {
+	rv := indirectValue(v.v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+	keys := make([]string, 0, rv.Len())
+	for it := rv.MapRange(); it.Next(); {
+		keys = append(keys, it.Key().String())
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Elems returns the elements of a list, and nil for anything else.
+func (v Value) Elems() []Value {
+	if k := indirectValue(v.v).Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil
+	}
+	elems := make([]Value, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i)
+	}
+	return elems
+}
+
+/*
+ValueAs converts v to T with the rules of To. A json.Number is first read as
+an int64, uint64 or float64 unless T is a string type, which receives the
+number as written. Lookup errors are returned as they are; conversion
+errors are *ConversionError.
+
+Example:
+
+	v, _ := convert.ParseJSON([]byte(`{"port": 8080}`))
+	port, _ := convert.ValueAs[uint16](v.Get("port")) // 8080
+*/
+func ValueAs[T any](v Value) (T, error) {
+	var zero T
+	if v.err != nil {
+		return zero, v.err
+	}
+	x := v.v
+	if n, ok := x.(json.Number); ok && reflect.TypeFor[T]().Kind() != reflect.String {
+		x = jsonNumberValue(n)
+	}
+	out, err := convertTo(x, any(zero))
+	if err != nil {
+		return zero, &ConversionError{Value: v.v, Target: reflect.TypeFor[T](), Err: err}
+	}
+	return out.(T), nil
+}
+
+// ValueOr is ValueAs returning def for any error, including a missing or
+// null value.
+func ValueOr[T any](v Value, def T) T {
+	out, err := ValueAs[T](v)
+	if err != nil {
+		return def
+	}
+	return out
+}
+
+// jsonNumberValue returns n as the first of int64, uint64 and float64 that
+// holds it exactly, or as a float64 if none does. Literals that are not
+// numbers stay json.Number and fail to convert.
+func jsonNumberValue(n json.Number) any {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u
+	}
+	if f, err := strconv.ParseFloat(string(n), 64); err == nil {
+		return f
+	}
+	return n
+}
+
+// Int returns v as an int.
+func (v Value) Int() (int, error) { return ValueAs[int](v) }
+
+// Int64 returns v as an int64.
+func (v Value) Int64() (int64, error) { return ValueAs[int64](v) }
+
+// Uint64 returns v as a uint64.
+func (v Value) Uint64() (uint64, error) { return ValueAs[uint64](v) }
+
+// Float64 returns v as a float64.
+func (v Value) Float64() (float64, error) { return ValueAs[float64](v) }
+
+// Bool returns v as a bool.
+func (v Value) Bool() (bool, error) { return ValueAs[bool](v) }
+
+// Str returns v as a string. Numbers from ParseJSON are returned as
+// written in the document. It is not named String so that Value does not
+// look like a fmt.Stringer.
+func (v Value) Str() (string, error) { return ValueAs[string](v) }
+
+// IntOr returns v as an int, or def if v is missing, null or not an int.
+func (v Value) IntOr(def int) int { return ValueOr(v, def) }
+
+// Int64Or returns v as an int64, or def if v is missing, null or not an
+// int64.
+func (v Value) Int64Or(def int64) int64 { return ValueOr(v, def) }
+
+// Uint64Or returns v as a uint64, or def if v is missing, null or not a
+// uint64.
+func (v Value) Uint64Or(def uint64) uint64 { return ValueOr(v, def) }
+
+// Float64Or returns v as a float64, or def if v is missing, null or not a
+// number.
+func (v Value) Float64Or(def float64) float64 { return ValueOr(v, def) }
+
+// BoolOr returns v as a bool, or def if v is missing, null or not a bool.
+func (v Value) BoolOr(def bool) bool { return ValueOr(v, def) }
+
+// StrOr returns v as a string, or def if v is missing, null or not a
+// scalar.
+func (v Value) StrOr(def string) string { return ValueOr(v, def) }
@@ -0,0 +1,233 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+const valueDoc = `{
+	"name": "app",
+	"debug": true,
+	"id": 9007199254740993,
+	"max": 18446744073709551615,
+	"ratio": 0.25,
+	"big": 1e3,
+	"nothing": null,
+	"server": {"host": "localhost", "ports": [80, 443, {"admin": 8443}]},
+	"x.y": {"[z]": "quoted"}
+}`
+
+func TestValueGet(t *testing.T) {
+	v, err := ParseJSON([]byte(valueDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want any
+	}{
+		{"name", "app"},
+		{"server.host", "localhost"},
+		{"server.ports[1]", json.Number("443")},
+		{"server.ports.1", json.Number("443")},
+		{"server.ports[2].admin", json.Number("8443")},
+		{`["x.y"]["[z]"]`, "quoted"},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		got := v.Get(tt.path)
+		if !got.Exists() || got.Interface() != tt.want {
+			t.Errorf("Get(%q) = %#v, %v, want %#v", tt.path, got.Interface(), got.Err(), tt.want)
+		}
+	}
+
+	if got := v.Get("server").Get("ports[2]").Get("admin").IntOr(0); got != 8443 {
+		t.Errorf("chained Get = %d, want 8443", got)
+	}
+	if got := v.Key("x.y").Key("[z]").StrOr(""); got != "quoted" {
+		t.Errorf("Key = %q, want quoted", got)
+	}
+	if got := v.Get("server.ports").Index(0).IntOr(0); got != 80 {
+		t.Errorf("Index(0) = %d, want 80", got)
+	}
+	if !v.Get("nothing").IsNull() || v.Get("name").IsNull() {
+		t.Error("IsNull reported the wrong values")
+	}
+
+	for _, tt := range []struct {
+		path string
+		err  error
+	}{
+		{"missing", ErrNotFound},
+		{"server.ports[3]", ErrNotFound},
+		{"server.host.name", ErrNotFound},
+		{"name[0]", ErrNotFound},
+		{"server..host", ErrSyntax},
+		{"server.", ErrSyntax},
+		{".server", ErrSyntax},
+		{"server.ports[x]", ErrSyntax},
+		{"server.ports[-1]", ErrSyntax},
+		{"server.ports[1", ErrSyntax},
+		{"server.ports[1]host", ErrSyntax},
+		{`["x.y"`, ErrSyntax},
+	} {
+		got := v.Get(tt.path)
+		var fe *FieldError
+		if got.Exists() || !errors.As(got.Err(), &fe) || !errors.Is(got.Err(), tt.err) {
+			t.Errorf("Get(%q) error = %v, want %v", tt.path, got.Err(), tt.err)
+		}
+	}
+
+	err = v.Get("server").Get("ports[9]").Get("admin").Err()
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Path != "server.ports[9]" {
+		t.Errorf("chained lookup error = %v, want path server.ports[9]", err)
+	}
+}
+
+func TestValueGetters(t *testing.T) {
+	v, err := ParseJSON([]byte(valueDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := v.Get("id").Int64(); err != nil || n != 9007199254740993 {
+		t.Errorf("Int64(id) = %d, %v, want 9007199254740993", n, err)
+	}
+	if n, err := v.Get("max").Uint64(); err != nil || n != math.MaxUint64 {
+		t.Errorf("Uint64(max) = %d, %v", n, err)
+	}
+	if _, err := v.Get("max").Int64(); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Int64(max) error = %v, want ErrOverflow", err)
+	}
+	if f, err := v.Get("ratio").Float64(); err != nil || f != 0.25 {
+		t.Errorf("Float64(ratio) = %v, %v", f, err)
+	}
+	if _, err := v.Get("ratio").Int(); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Int(ratio) error = %v, want ErrTruncated", err)
+	}
+	if n, err := v.Get("big").Int(); err != nil || n != 1000 {
+		t.Errorf("Int(big) = %d, %v, want 1000", n, err)
+	}
+	if s, err := v.Get("big").Str(); err != nil || s != "1e3" {
+		t.Errorf("String(big) = %q, %v, want 1e3", s, err)
+	}
+	if b, err := v.Get("debug").Bool(); err != nil || !b {
+		t.Errorf("Bool(debug) = %v, %v", b, err)
+	}
+	if p, err := ValueAs[uint16](v.Get("server.ports[2].admin")); err != nil || p != 8443 {
+		t.Errorf("ValueAs[uint16] = %d, %v", p, err)
+	}
+	var ce *ConversionError
+	if _, err := ValueAs[uint8](v.Get("server.ports[1]")); !errors.As(err, &ce) || !errors.Is(err, ErrOverflow) {
+		t.Errorf("ValueAs[uint8](443) error = %v, want *ConversionError wrapping ErrOverflow", err)
+	}
+	if _, err := v.Get("missing").Int(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Int(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := v.Get("server").Str(); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("String(server) error = %v, want ErrUnsupportedType", err)
+	}
+
+	if got := v.Get("missing").IntOr(8080); got != 8080 {
+		t.Errorf("IntOr(missing) = %d", got)
+	}
+	if got := v.Get("nothing").StrOr("none"); got != "none" {
+		t.Errorf("StrOr(null) = %q", got)
+	}
+	if got := v.Get("name").BoolOr(true); !got {
+		t.Errorf("BoolOr(name) = %v", got)
+	}
+	if got := v.Get("id").Int64Or(0); got != 9007199254740993 {
+		t.Errorf("Int64Or(id) = %d", got)
+	}
+	if got := v.Get("ratio").Float64Or(1); got != 0.25 {
+		t.Errorf("Float64Or(ratio) = %v", got)
+	}
+	if got := v.Get("max").Uint64Or(0); got != math.MaxUint64 {
+		t.Errorf("Uint64Or(max) = %d", got)
+	}
+	if got := ValueOr(v.Get("server.ports[0]"), int8(1)); got != 80 {
+		t.Errorf("ValueOr[int8] = %d", got)
+	}
+}
+
+func TestValueCollections(t *testing.T) {
+	v, err := ParseJSON([]byte(valueDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports := v.Get("server.ports")
+	if ports.Len() != 3 || len(ports.Elems()) != 3 || ports.Keys() != nil {
+		t.Errorf("ports: Len %d, Elems %d, Keys %v", ports.Len(), len(ports.Elems()), ports.Keys())
+	}
+	if got := v.Get("server").Keys(); !slices.Equal(got, []string{"host", "ports"}) {
+		t.Errorf("Keys(server) = %v", got)
+	}
+	if v.Get("server").Elems() != nil || v.Get("name").Len() != 0 {
+		t.Error("Elems or Len accepted a non-list")
+	}
+
+	// Plain Go data is traversed too, including typed maps and slices.
+	cfg := ValueOf(map[string]any{
+		"limits": map[string]int{"cpu": 2},
+		"tags":   []string{"a", "b"},
+		"ptr":    &struct{}{},
+		"nested": &map[string]any{"x": 1.0},
+	})
+	if got := cfg.Get("limits.cpu").IntOr(0); got != 2 {
+		t.Errorf("typed map lookup = %d", got)
+	}
+	if got := cfg.Get("tags[1]").StrOr(""); got != "b" || cfg.Get("tags").Len() != 2 {
+		t.Errorf("typed slice lookup = %q", got)
+	}
+	if got := cfg.Get("nested.x").IntOr(0); got != 1 {
+		t.Errorf("pointer lookup = %d", got)
+	}
+	if cfg.Get("ptr.x").Exists() {
+		t.Error("lookup into a struct succeeded")
+	}
+}
+
+func TestValueJSON(t *testing.T) {
+	if _, err := ParseJSON([]byte(`{"a": 1} {"b": 2}`)); !errors.Is(err, ErrSyntax) {
+		t.Errorf("trailing data error = %v, want ErrSyntax", err)
+	}
+	if _, err := ParseJSON([]byte(`{"a": `)); err == nil {
+		t.Error("truncated JSON parsed")
+	}
+
+	var doc struct {
+		Kind  string `json:"kind"`
+		Extra Value  `json:"extra"`
+	}
+	in := `{"kind":"x","extra":{"f":1.50,"id":12345678901234567890}}`
+	if err := json.Unmarshal([]byte(in), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := doc.Extra.Get("id").Uint64(); err != nil || n != 12345678901234567890 {
+		t.Errorf("embedded Value id = %d, %v", n, err)
+	}
+	out, err := json.Marshal(doc)
+	if err != nil || string(out) != in {
+		t.Errorf("Marshal = %s, %v, want %s", out, err, in)
+	}
+	if _, err := json.Marshal(ValueOf(nil).Get("missing")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Marshal(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func BenchmarkValueGet(b *testing.B) {
+	v, err := ParseJSON([]byte(valueDoc))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = v.Get("server.ports[2].admin").IntOr(0)
+	}
+}
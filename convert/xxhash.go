@@ -0,0 +1,138 @@
+package convert
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// This file implements the 64-bit xxHash (XXH64) algorithm.
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+// xxh64 is a streaming XXH64 hash.Hash64.
+type xxh64 struct {
+	seed  uint64
+	v     [4]uint64
+	total uint64
+	buf   [32]byte
+	n     int
+}
+
+// NewXXH64 returns a streaming XXH64 hash with the given seed. Sum
+// appends the big-endian digest, matching the canonical hex form.
+func NewXXH64(seed uint64) hash.Hash64 {
+	d := &xxh64{seed: seed}
+	d.Reset()
+	return d
+}
+
+// XXH64Sum returns the XXH64 digest of data with seed 0. It is fast and
+// well distributed but not cryptographic, which suits sharding and hash
+// tables.
+func XXH64Sum(data []byte) uint64 {
+	d := xxh64{}
+	d.Reset()
+	_, _ = d.Write(data)
+	return d.Sum64()
+}
+
+func (d *xxh64) Size() int      { return 8 }
+func (d *xxh64) BlockSize() int { return 32 }
+
+func (d *xxh64) Reset() {
+	d.v = [4]uint64{
+		d.seed + xxPrime1 + xxPrime2,
+		d.seed + xxPrime2,
+		d.seed,
+		d.seed - xxPrime1,
+	}
+	d.total = 0
+	d.n = 0
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	acc ^= xxRound(0, val)
+	return acc*xxPrime1 + xxPrime4
+}
+
+func (d *xxh64) Write(p []byte) (int, error) {
+	written := len(p)
+	d.total += uint64(written) // #nosec G115 -- length is never negative
+	if d.n > 0 {
+		c := copy(d.buf[d.n:], p)
+		d.n += c
+		p = p[c:]
+		if d.n < len(d.buf) {
+			return written, nil
+		}
+		d.stripe(d.buf[:])
+		d.n = 0
+	}
+	for len(p) >= 32 {
+		d.stripe(p[:32])
+		p = p[32:]
+	}
+	d.n = copy(d.buf[:], p)
+	return written, nil
+}
+
+func (d *xxh64) stripe(b []byte) {
+	d.v[0] = xxRound(d.v[0], binary.LittleEndian.Uint64(b[0:]))
+	d.v[1] = xxRound(d.v[1], binary.LittleEndian.Uint64(b[8:]))
+	d.v[2] = xxRound(d.v[2], binary.LittleEndian.Uint64(b[16:]))
+	d.v[3] = xxRound(d.v[3], binary.LittleEndian.Uint64(b[24:]))
+}
+
+func (d *xxh64) Sum64() uint64 {
+	var h uint64
+	if d.total >= 32 {
+		v := d.v
+		h = bits.RotateLeft64(v[0], 1) + bits.RotateLeft64(v[1], 7) +
+			bits.RotateLeft64(v[2], 12) + bits.RotateLeft64(v[3], 18)
+		for _, x := range v {
+			h = xxMergeRound(h, x)
+		}
+	} else {
+		h = d.seed + xxPrime5
+	}
+	h += d.total
+
+	b := d.buf[:d.n]
+	for ; len(b) >= 8; b = b[8:] {
+		h ^= xxRound(0, binary.LittleEndian.Uint64(b))
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+	}
+	if len(b) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(b)) * xxPrime1
+		h = bits.RotateLeft64(h, 23)*xxPrime2 + xxPrime3
+		b = b[4:]
+	}
+	for _, c := range b {
+		h ^= uint64(c) * xxPrime5
+		h = bits.RotateLeft64(h, 11) * xxPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
+
+func (d *xxh64) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint64(b, d.Sum64())
+}
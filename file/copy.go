@@ -0,0 +1,555 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotRegular is reported for sources that are neither regular files,
+	// directories nor symlinks, such as devices and named pipes.
+	ErrNotRegular = errors.New("not a regular file")
+	// ErrSymlinkLoop is reported when following symlinks leads back into a
+	// directory that is already being copied.
+	ErrSymlinkLoop = errors.New("symlink loop")
+)
+
+// OverwritePolicy decides what happens when a destination file exists.
+type OverwritePolicy int
+
+const (
+	// OverwriteNever reports an existing destination as an error wrapping
+	// fs.ErrExist, as Copy does.
+	OverwriteNever OverwritePolicy = iota
+	// OverwriteAlways replaces existing destinations.
+	OverwriteAlways
+	// OverwriteIfNewer replaces a destination only when the source was
+	// modified after it, and skips it otherwise.
+	OverwriteIfNewer
+	// OverwriteSkip keeps existing destinations without reporting them.
+	OverwriteSkip
+)
+
+// SymlinkPolicy decides how symbolic links in the source are copied.
+type SymlinkPolicy int
+
+const (
+	// SymlinkRecreate creates a link with the same target in the
+	// destination. Relative targets are kept as they are.
+	SymlinkRecreate SymlinkPolicy = iota
+	// SymlinkFollow copies the file or directory the link points to.
+	// Broken links and loops are reported as errors.
+	SymlinkFollow
+	// SymlinkSkip leaves links out.
+	SymlinkSkip
+)
+
+// CopyProgress is passed to CopyOptions.Progress as data is copied.
+type CopyProgress struct {
+	// Path is the source path of the file being copied.
+	Path string
+	// Written is the number of bytes of Path copied so far, and Size its
+	// total size.
+	Written, Size int64
+	// Total is the number of bytes copied by the whole operation so far.
+	Total int64
+	// Files is the number of files and symlinks completed so far.
+	Files int
+}
+
+// CopyOptions configures CopyWithOptions and CopyDir. A nil *CopyOptions
+// uses the zero value: existing files are errors, symlinks are recreated,
+// and new files get default permissions and the current time.
+type CopyOptions struct {
+	Overwrite OverwritePolicy
+	Symlinks  SymlinkPolicy
+
+	// PreserveMode copies permission bits, including setuid, setgid and
+	// sticky. Without it, files are created with 0666 and directories with
+	// 0777, less the umask.
+	PreserveMode bool
+	// PreserveOwner copies the user and group, which usually requires
+	// root. It is ignored on platforms without Unix ownership.
+	PreserveOwner bool
+	// PreserveTimes copies the modification time of files and directories.
+	PreserveTimes bool
+
+	// Include, if not empty, limits the copy to files and symlinks that
+	// match one of its patterns. Directories are always searched, but only
+	// created when something inside them is copied.
+	Include []string
+	// Exclude leaves out files, symlinks and whole directories that match
+	// one of its patterns.
+	Exclude []string
+
+	// Progress, if set, is called after every chunk of data written and
+	// once for each file or symlink completed.
+	Progress func(CopyProgress)
+}
+
+/*
+CopyWithOptions copies src to dst: a regular file, a symlink (see
+CopyOptions.Symlinks) or a whole directory tree, as CopyDir does. Unlike
+Copy it can replace existing files and keep the source's mode, owner and
+modification time.
+
+Include and Exclude patterns use path.Match syntax. A pattern containing a
+slash is matched against the path relative to src, with slashes, such as
+"docs/*.md"; other patterns are matched against the base name, such as
+"*.tmp" or ".git". They apply inside directory trees, not to src itself.
+
+The copy continues past failures. The returned error joins an
+*fs.PathError for every path that failed, so errors.As finds the first one
+and errors.Is works with fs.ErrExist, fs.ErrPermission and the like.
+Copying a file onto itself, or onto a hard link to it, is an error wrapping
+fs.ErrInvalid. When ctx is canceled the copy stops and the error includes
+ctx.Err(). Files are written to a temporary file in the destination
+directory and renamed into place, so a failed or canceled copy leaves an
+existing destination as it was.
+
+Example:
+
+	err := file.CopyWithOptions(ctx, "site", "/var/www/site", &file.CopyOptions{
+		Overwrite:     file.OverwriteIfNewer,
+		PreserveMode:  true,
+		PreserveTimes: true,
+		Exclude:       []string{".git", "*.tmp"},
+	})
+*/
+func CopyWithOptions(ctx context.Context, src, dst string, opts *CopyOptions) error {
+	c, err := newCopier(ctx, opts)
+	if err != nil {
+		return err
+	}
+	info, err := os.Lstat(src)
+	if err == nil && info.Mode()&fs.ModeSymlink != 0 && c.opts.Symlinks == SymlinkFollow {
+		info, err = os.Stat(src)
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case info.IsDir():
+		c.copyRoot(src, dst, info)
+	case info.Mode()&fs.ModeSymlink != 0:
+		if c.opts.Symlinks != SymlinkSkip {
+			c.copySymlink(src, dst, info)
+		}
+	default:
+		c.copyFile(src, dst, info)
+	}
+	return c.result()
+}
+
+/*
+CopyDir copies the contents of the directory src into dst, creating dst if
+it does not exist and merging into it if it does. A symlink given as src is
+followed; symlinks inside the tree are handled by opts.Symlinks. Options and
+errors are as for CopyWithOptions.
+
+Example:
+
+	err := file.CopyDir(ctx, "testdata", t.TempDir(), nil)
+*/
+func CopyDir(ctx context.Context, src, dst string, opts *CopyOptions) error {
+	c, err := newCopier(ctx, opts)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return &fs.PathError{Op: "copy", Path: src, Err: fmt.Errorf("%w: not a directory", fs.ErrInvalid)}
+	}
+	c.copyRoot(src, dst, info)
+	return c.result()
+}
+
+type copier struct {
+	ctx  context.Context
+	opts CopyOptions
+	errs []error
+	// active holds the resolved paths of the directories being copied, to
+	// detect loops when following symlinks.
+	active map[string]bool
+	total  int64
+	files  int
+}
+
+func newCopier(ctx context.Context, opts *CopyOptions) (*copier, error) {
+	c := &copier{ctx: ctx, active: make(map[string]bool)}
+	if opts != nil {
+		c.opts = *opts
+	}
+	for _, p := range slices.Concat(c.opts.Include, c.opts.Exclude) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("file: pattern %q: %w", p, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *copier) fail(op, path string, err error) {
+	var pe *fs.PathError
+	if !errors.As(err, &pe) {
+		err = &fs.PathError{Op: op, Path: path, Err: err}
+	}
+	c.errs = append(c.errs, err)
+}
+
+// canceled reports whether ctx is done. The context error is added to the
+// result once, by result.
+func (c *copier) canceled() bool { return c.ctx.Err() != nil }
+
+func (c *copier) result() error {
+	if err := c.ctx.Err(); err != nil {
+		c.errs = append(c.errs, err)
+	}
+	return errors.Join(c.errs...)
+}
+
+func (c *copier) progress(p CopyProgress) {
+	if c.opts.Progress != nil {
+		p.Total, p.Files = c.total, c.files
+		c.opts.Progress(p)
+	}
+}
+
+// matches reports whether rel, a slash-separated relative path, matches one
+// of patterns.
+func matches(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		name := path.Base(rel)
+		if strings.Contains(p, "/") {
+			name = rel
+		}
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// copyRoot copies the directory src into dst, refusing to copy a directory
+// into itself, including through symlinks.
+func (c *copier) copyRoot(src, dst string, info fs.FileInfo) {
+	realSrc, err1 := resolvePath(src)
+	realDst, err2 := resolvePath(dst)
+	if err := errors.Join(err1, err2); err != nil {
+		c.fail("copy", src, err)
+		return
+	}
+	if rel, err := filepath.Rel(realSrc, realDst); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		c.fail("copy", dst, fmt.Errorf("%w: destination is inside the source", fs.ErrInvalid))
+		return
+	}
+	c.copyDir(src, dst, "", info, nil)
+}
+
+// resolvePath returns the absolute form of p with symlinks resolved. When p
+// does not exist yet, its nearest existing parent is resolved and the rest
+// of p appended.
+func resolvePath(p string) (string, error) {
+	p, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+	rest := ""
+	for {
+		resolved, err := filepath.EvalSymlinks(p)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		parent := filepath.Dir(p)
+		if !errors.Is(err, fs.ErrNotExist) || parent == p {
+			return "", err
+		}
+		rest = filepath.Join(filepath.Base(p), rest)
+		p = parent
+	}
+}
+
+// copyDir copies the contents of src into dst. rel is the slash-separated
+// path of src below the root. The destination is created by ensure, on
+// demand when Include is set; parentEnsure creates its parent.
+func (c *copier) copyDir(src, dst, rel string, info fs.FileInfo, parentEnsure func() bool) {
+	if c.canceled() {
+		return
+	}
+	if real, err := filepath.EvalSymlinks(src); err == nil {
+		if c.active[real] {
+			c.fail("copy", src, ErrSymlinkLoop)
+			return
+		}
+		c.active[real] = true
+		defer delete(c.active, real)
+	}
+
+	created, failed := false, false
+	ensure := func() bool {
+		if created || failed {
+			return created
+		}
+		if parentEnsure != nil && !parentEnsure() {
+			failed = true
+			return false
+		}
+		if err := os.Mkdir(dst, 0o777); err != nil && !errors.Is(err, fs.ErrExist) {
+			c.fail("mkdir", dst, err)
+			failed = true
+			return false
+		}
+		if st, err := os.Stat(dst); err != nil || !st.IsDir() {
+			c.fail("mkdir", dst, fmt.Errorf("%w: not a directory", fs.ErrExist))
+			failed = true
+			return false
+		}
+		created = true
+		return true
+	}
+	if len(c.opts.Include) == 0 && !ensure() {
+		return
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		c.fail("readdir", src, err)
+	}
+	for _, e := range entries {
+		if c.canceled() {
+			return
+		}
+		s, d := filepath.Join(src, e.Name()), filepath.Join(dst, e.Name())
+		r := path.Join(rel, e.Name())
+		if matches(c.opts.Exclude, r) {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			c.fail("lstat", s, err)
+			continue
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			switch c.opts.Symlinks {
+			case SymlinkSkip:
+				continue
+			case SymlinkFollow:
+				if fi, err = os.Stat(s); err != nil {
+					c.fail("stat", s, err)
+					continue
+				}
+			}
+		}
+		if fi.IsDir() {
+			c.copyDir(s, d, r, fi, ensure)
+			continue
+		}
+		if len(c.opts.Include) > 0 && !matches(c.opts.Include, r) {
+			continue
+		}
+		if !ensure() {
+			continue
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			c.copySymlink(s, d, fi)
+		} else {
+			c.copyFile(s, d, fi)
+		}
+	}
+	if created {
+		c.preserve(dst, info, false)
+	}
+}
+
+// replace checks an existing destination against the overwrite policy. A
+// symlink destination is removed first when link is set; files replace
+// theirs by renaming. It returns the destination's FileInfo, nil if it does
+// not exist, and whether to go on with the copy.
+func (c *copier) replace(dst string, info fs.FileInfo, link bool) (fs.FileInfo, bool) {
+	existing, err := os.Lstat(dst)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, true
+	}
+	if err != nil {
+		c.fail("lstat", dst, err)
+		return nil, false
+	}
+	switch c.opts.Overwrite {
+	case OverwriteNever:
+		c.fail("copy", dst, fs.ErrExist)
+		return nil, false
+	case OverwriteSkip:
+		return nil, false
+	case OverwriteIfNewer:
+		if !info.ModTime().After(existing.ModTime()) {
+			return nil, false
+		}
+	}
+	if existing.IsDir() {
+		c.fail("copy", dst, fmt.Errorf("%w: destination is a directory", fs.ErrExist))
+		return nil, false
+	}
+	// Copying a file onto itself, or onto a hard link to it, would destroy
+	// it.
+	target, err := os.Stat(dst)
+	if os.SameFile(existing, info) || (err == nil && os.SameFile(target, info)) {
+		c.fail("copy", dst, fmt.Errorf("%w: source and destination are the same file", fs.ErrInvalid))
+		return nil, false
+	}
+	if link {
+		if err := os.Remove(dst); err != nil {
+			c.fail("remove", dst, err)
+			return nil, false
+		}
+	}
+	return existing, true
+}
+
+// copyFile copies the regular file src to dst. The data is written to a
+// temporary file next to dst, which is renamed over dst once complete, so
+// an existing dst is left intact if the copy fails or is canceled.
+func (c *copier) copyFile(src, dst string, info fs.FileInfo) {
+	if !info.Mode().IsRegular() {
+		c.fail("copy", src, ErrNotRegular)
+		return
+	}
+	existing, ok := c.replace(dst, info, false)
+	if !ok {
+		return
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		c.fail("open", src, err)
+		return
+	}
+	defer in.Close()
+
+	// A replaced file keeps its permissions unless PreserveMode is set.
+	perm := fs.FileMode(0o666)
+	switch {
+	case c.opts.PreserveMode:
+		perm = info.Mode().Perm()
+	case existing != nil && existing.Mode().IsRegular():
+		perm = existing.Mode().Perm()
+	}
+	out, err := createTemp(dst, perm)
+	if err != nil {
+		c.fail("open", dst, err)
+		return
+	}
+	tmp := out.Name()
+	if existing != nil && !c.opts.PreserveMode {
+		// The umask applied at creation must not narrow the kept mode.
+		err = out.Chmod(perm)
+	}
+	r := &progressReader{c: c, r: in, p: CopyProgress{Path: src, Size: info.Size()}}
+	if err == nil {
+		_, err = io.Copy(out, r)
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, dst)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		if !c.canceled() {
+			c.fail("copy", src, err)
+		}
+		return
+	}
+	c.preserve(dst, info, false)
+	c.files++
+	c.progress(r.p)
+}
+
+// createTemp creates a new file with mode perm, before the umask, in the
+// directory of dst.
+func createTemp(dst string, perm fs.FileMode) (*os.File, error) {
+	dir, base := filepath.Split(dst)
+	for {
+		name := filepath.Join(dir, "."+base+".tmp"+strconv.FormatUint(uint64(rand.Uint32()), 36))
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if !errors.Is(err, fs.ErrExist) {
+			return f, err
+		}
+	}
+}
+
+// copySymlink recreates the symlink src as dst.
+func (c *copier) copySymlink(src, dst string, info fs.FileInfo) {
+	target, err := os.Readlink(src)
+	if err != nil {
+		c.fail("readlink", src, err)
+		return
+	}
+	if _, ok := c.replace(dst, info, true); !ok {
+		return
+	}
+	if err := os.Symlink(target, dst); err != nil {
+		c.fail("symlink", dst, err)
+		return
+	}
+	c.preserve(dst, info, true)
+	c.files++
+	c.progress(CopyProgress{Path: src})
+}
+
+// preserve applies the owner, mode and modification time of info to dst
+// as the options ask. Links only get their owner.
+func (c *copier) preserve(dst string, info fs.FileInfo, link bool) {
+	if c.opts.PreserveOwner {
+		if err := lchown(dst, info); err != nil {
+			c.fail("chown", dst, err)
+		}
+	}
+	if link {
+		return
+	}
+	if c.opts.PreserveMode {
+		mode := info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)
+		if err := os.Chmod(dst, mode); err != nil {
+			c.fail("chmod", dst, err)
+		}
+	}
+	if c.opts.PreserveTimes {
+		if err := os.Chtimes(dst, time.Time{}, info.ModTime()); err != nil {
+			c.fail("chtimes", dst, err)
+		}
+	}
+}
+
+// progressReader stops reading when the context is done and reports each
+// chunk to the progress callback.
+type progressReader struct {
+	c *copier
+	r io.Reader
+	p CopyProgress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	if err := r.c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := r.r.Read(b)
+	if n > 0 {
+		r.p.Written += int64(n)
+		r.c.total += int64(n)
+		r.c.progress(r.p)
+	}
+	return n, err
+}
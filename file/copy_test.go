@@ -0,0 +1,260 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTree creates files under root from a map of slash-separated paths to
+// contents.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, body := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// readTree lists the files, directories (with a trailing slash) and symlinks
+// (as "name -> target") under root.
+func readTree(t *testing.T, root string) []string {
+	t.Helper()
+	var got []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == root {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		switch {
+		case d.IsDir():
+			got = append(got, rel+"/")
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			got = append(got, rel+" -> "+target)
+		default:
+			b, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			got = append(got, rel+"="+string(b))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	return got
+}
+
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	writeTree(t, src, map[string]string{
+		"a.txt":       "a",
+		"sub/b.txt":   "b",
+		"sub/c.tmp":   "c",
+		"docs/x.md":   "x",
+		".git/HEAD":   "ref",
+		"empty/.keep": "",
+	})
+
+	tests := []struct {
+		name string
+		opts *CopyOptions
+		want []string
+	}{
+		{
+			name: "defaults",
+			want: []string{".git/", ".git/HEAD=ref", "a.txt=a", "docs/", "docs/x.md=x", "empty/", "empty/.keep=",
+				"sub/", "sub/b.txt=b", "sub/c.tmp=c"},
+		},
+		{
+			name: "exclude",
+			opts: &CopyOptions{Exclude: []string{".git", "*.tmp", "docs/*"}},
+			want: []string{"a.txt=a", "docs/", "empty/", "empty/.keep=", "sub/", "sub/b.txt=b"},
+		},
+		{
+			name: "include creates only needed directories",
+			opts: &CopyOptions{Include: []string{"*.txt"}, Exclude: []string{"a.*"}},
+			want: []string{"sub/", "sub/b.txt=b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := filepath.Join(t.TempDir(), "out")
+			if err := CopyDir(context.Background(), src, dst, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if got := readTree(t, dst); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tree = %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyOverwrite(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	writeTree(t, src, map[string]string{"old": "new old", "new": "new new", "only": "only"})
+	writeTree(t, dst, map[string]string{"old": "dst old", "new": "dst new"})
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	for name, mtime := range map[string]time.Time{"old": past, "new": future} {
+		if err := os.Chtimes(filepath.Join(src, name), time.Time{}, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := CopyDir(context.Background(), src, dst, nil)
+	var pe *fs.PathError
+	if !errors.Is(err, fs.ErrExist) || !errors.As(err, &pe) || len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Fatalf("CopyDir error = %v, want two fs.ErrExist path errors", err)
+	}
+	want := []string{"new=dst new", "old=dst old", "only=only"}
+	if got := readTree(t, dst); !reflect.DeepEqual(got, want) {
+		t.Errorf("OverwriteNever tree = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		policy OverwritePolicy
+		want   []string
+	}{
+		{OverwriteSkip, []string{"new=dst new", "old=dst old", "only=only"}},
+		{OverwriteIfNewer, []string{"new=new new", "old=dst old", "only=only"}},
+		{OverwriteAlways, []string{"new=new new", "old=new old", "only=only"}},
+	}
+	for _, tt := range tests {
+		if err := CopyDir(context.Background(), src, dst, &CopyOptions{Overwrite: tt.policy}); err != nil {
+			t.Fatalf("policy %d: %v", tt.policy, err)
+		}
+		if got := readTree(t, dst); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("policy %d tree = %q, want %q", tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestCopyOntoItself(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{"a": "data"})
+	a, link := filepath.Join(dir, "a"), filepath.Join(dir, "hard")
+	if err := os.Link(a, link); err != nil {
+		t.Skip(err)
+	}
+
+	opts := &CopyOptions{Overwrite: OverwriteAlways}
+	for _, dst := range []string{a, link} {
+		if err := CopyWithOptions(context.Background(), a, dst, opts); !errors.Is(err, fs.ErrInvalid) {
+			t.Errorf("copy onto %s error = %v, want fs.ErrInvalid", dst, err)
+		}
+		if b, err := os.ReadFile(a); err != nil || string(b) != "data" {
+			t.Errorf("source after copy onto %s = %q, %v", dst, b, err)
+		}
+	}
+	if err := CopyDir(context.Background(), dir, dir, opts); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("CopyDir onto itself error = %v, want fs.ErrInvalid", err)
+	}
+}
+
+func TestCopyProgressAndCancel(t *testing.T) {
+	src := t.TempDir()
+	writeTree(t, src, map[string]string{
+		"a": strings.Repeat("a", 100_000),
+		"b": strings.Repeat("b", 10),
+	})
+
+	var last CopyProgress
+	var calls int
+	opts := &CopyOptions{Progress: func(p CopyProgress) {
+		calls++
+		if p.Written > p.Size || p.Total < last.Total {
+			t.Errorf("bad progress %+v after %+v", p, last)
+		}
+		last = p
+	}}
+	if err := CopyDir(context.Background(), src, filepath.Join(t.TempDir(), "out"), opts); err != nil {
+		t.Fatal(err)
+	}
+	if last.Total != 100_010 || last.Files != 2 || calls < 4 {
+		t.Errorf("last progress = %+v after %d calls, want 100010 bytes in 2 files", last, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	dst := filepath.Join(t.TempDir(), "out")
+	opts.Progress = func(CopyProgress) { cancel() }
+	err := CopyDir(ctx, src, dst, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("CopyDir error = %v, want context.Canceled", err)
+	}
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		t.Errorf("cancellation reported as a path error: %v", pe)
+	}
+	// The interrupted file is removed rather than left truncated.
+	if got := readTree(t, dst); len(got) != 0 {
+		t.Errorf("tree after cancel = %q, want empty", got)
+	}
+}
+
+func TestCopyKeepsDestinationOnCancel(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	writeTree(t, src, map[string]string{"a": strings.Repeat("a", 100_000)})
+	writeTree(t, dst, map[string]string{"a": "keep"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := &CopyOptions{Overwrite: OverwriteAlways, Progress: func(CopyProgress) { cancel() }}
+	if err := CopyDir(ctx, src, dst, opts); !errors.Is(err, context.Canceled) {
+		t.Fatalf("CopyDir error = %v, want context.Canceled", err)
+	}
+	// Neither the old file nor a temporary file is lost or left behind.
+	if got := readTree(t, dst); !reflect.DeepEqual(got, []string{"a=keep"}) {
+		t.Errorf("tree after cancel = %q, want the old file", got)
+	}
+}
+
+func TestCopyErrors(t *testing.T) {
+	src := t.TempDir()
+	writeTree(t, src, map[string]string{"f": "f"})
+	ctx := context.Background()
+
+	if err := CopyDir(ctx, src, filepath.Join(src, "inner"), nil); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("copy into itself error = %v, want fs.ErrInvalid", err)
+	}
+	if err := CopyDir(ctx, filepath.Join(src, "f"), t.TempDir(), nil); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("CopyDir(file) error = %v, want fs.ErrInvalid", err)
+	}
+	if err := CopyWithOptions(ctx, filepath.Join(src, "none"), t.TempDir(), nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing source error = %v, want fs.ErrNotExist", err)
+	}
+	if err := CopyDir(ctx, src, t.TempDir(), &CopyOptions{Exclude: []string{"["}}); !errors.Is(err, path.ErrBadPattern) {
+		t.Errorf("bad pattern error = %v, want ErrBadPattern", err)
+	}
+
+	// A file in the way of a directory fails that path only.
+	writeTree(t, src, map[string]string{"sub/g": "g"})
+	dst := t.TempDir()
+	writeTree(t, dst, map[string]string{"sub": "file"})
+	err := CopyDir(ctx, src, dst, nil)
+	var pe *fs.PathError
+	if !errors.As(err, &pe) || pe.Path != filepath.Join(dst, "sub") {
+		t.Errorf("CopyDir error = %v, want a path error for sub", err)
+	}
+	if got := readTree(t, dst); !reflect.DeepEqual(got, []string{"f=f", "sub=file"}) {
+		t.Errorf("tree = %q", got)
+	}
+}
@@ -0,0 +1,184 @@
+//go:build unix
+
+package file
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCopySymlinks(t *testing.T) {
+	src := t.TempDir()
+	writeTree(t, src, map[string]string{"a.txt": "a", "sub/b.txt": "b"})
+	if err := os.Symlink("a.txt", filepath.Join(src, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts *CopyOptions
+		want []string
+	}{
+		{
+			name: "recreate",
+			want: []string{"a.txt=a", "link -> a.txt", "sub/", "sub/b.txt=b"},
+		},
+		{
+			name: "follow",
+			opts: &CopyOptions{Symlinks: SymlinkFollow, Include: []string{"link", "a.txt"}},
+			want: []string{"a.txt=a", "link=a"},
+		},
+		{
+			name: "skip",
+			opts: &CopyOptions{Symlinks: SymlinkSkip, Exclude: []string{"sub"}},
+			want: []string{"a.txt=a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := filepath.Join(t.TempDir(), "out")
+			if err := CopyDir(context.Background(), src, dst, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if got := readTree(t, dst); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tree = %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+
+	// A symlink in the destination is replaced, not written through.
+	target := filepath.Join(t.TempDir(), "target")
+	writeTree(t, filepath.Dir(target), map[string]string{"target": "keep"})
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+	opts := &CopyOptions{Overwrite: OverwriteAlways}
+	if err := CopyWithOptions(context.Background(), filepath.Join(src, "a.txt"), link, opts); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(target); string(b) != "keep" {
+		t.Errorf("symlink target was overwritten with %q", b)
+	}
+	if fi, err := os.Lstat(link); err != nil || !fi.Mode().IsRegular() {
+		t.Errorf("destination symlink was not replaced: %v, %v", fi, err)
+	}
+}
+
+func TestCopyPreserve(t *testing.T) {
+	src := t.TempDir()
+	writeTree(t, src, map[string]string{"bin/run": "#!/bin/sh"})
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, p := range []string{"bin/run", "bin"} {
+		p = filepath.Join(src, p)
+		if err := os.Chmod(p, 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(p, time.Time{}, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst := filepath.Join(t.TempDir(), "out")
+	opts := &CopyOptions{PreserveMode: true, PreserveTimes: true, PreserveOwner: true}
+	if err := CopyWithOptions(context.Background(), src, dst, opts); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"bin/run", "bin"} {
+		fi, err := os.Stat(filepath.Join(dst, p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != 0o750 {
+			t.Errorf("%s mode = %v, want 0750", p, fi.Mode().Perm())
+		}
+		if !fi.ModTime().Equal(mtime) {
+			t.Errorf("%s mtime = %v, want %v", p, fi.ModTime(), mtime)
+		}
+		if st, ok := fi.Sys().(*syscall.Stat_t); ok && int(st.Uid) != os.Getuid() {
+			t.Errorf("%s uid = %d, want %d", p, st.Uid, os.Getuid())
+		}
+	}
+
+	if err := CopyWithOptions(context.Background(), filepath.Join(src, "bin/run"), filepath.Join(dst, "plain"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(filepath.Join(dst, "plain")); err != nil || fi.ModTime().Equal(mtime) {
+		t.Errorf("default copy kept the source mtime: %v, %v", fi, err)
+	}
+}
+
+func TestCopySymlinkLoop(t *testing.T) {
+	src := t.TempDir()
+	writeTree(t, src, map[string]string{"sub/f": "f"})
+	if err := os.Symlink("..", filepath.Join(src, "sub", "up")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("missing", filepath.Join(src, "broken")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "out")
+	err := CopyDir(context.Background(), src, dst, &CopyOptions{Symlinks: SymlinkFollow})
+	if !errors.Is(err, ErrSymlinkLoop) || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("CopyDir error = %v, want ErrSymlinkLoop and fs.ErrNotExist", err)
+	}
+	want := []string{"sub/", "sub/f=f"}
+	if got := readTree(t, dst); !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+
+	// Recreated links are copied as they are, even when they loop.
+	dst = filepath.Join(t.TempDir(), "out")
+	if err := CopyDir(context.Background(), src, dst, nil); err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"broken -> missing", "sub/", "sub/f=f", "sub/up -> .."}
+	if got := readTree(t, dst); !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+}
+
+func TestCopyIntoSourceThroughSymlink(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	writeTree(t, src, map[string]string{"f": "f"})
+	alias := filepath.Join(root, "alias")
+	if err := os.Symlink(src, alias); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dst := range []string{filepath.Join(alias, "sub"), filepath.Join(alias, "new", "deeper")} {
+		if err := CopyDir(context.Background(), src, dst, nil); !errors.Is(err, fs.ErrInvalid) {
+			t.Errorf("CopyDir(%s) error = %v, want fs.ErrInvalid", dst, err)
+		}
+	}
+	if got := readTree(t, src); !reflect.DeepEqual(got, []string{"f=f"}) {
+		t.Errorf("source tree = %q, want it untouched", got)
+	}
+}
+
+func TestCopySpecialFile(t *testing.T) {
+	src := t.TempDir()
+	writeTree(t, src, map[string]string{"f": "f"})
+	fifo := filepath.Join(src, "fifo")
+	if err := syscall.Mkfifo(fifo, 0o644); err != nil {
+		t.Skip(err)
+	}
+	dst := filepath.Join(t.TempDir(), "out")
+	err := CopyDir(context.Background(), src, dst, nil)
+	var pe *fs.PathError
+	if !errors.Is(err, ErrNotRegular) || !errors.As(err, &pe) || pe.Path != fifo {
+		t.Errorf("CopyDir(fifo) error = %v, want ErrNotRegular for %s", err, fifo)
+	}
+	if got := readTree(t, dst); !reflect.DeepEqual(got, []string{"f=f"}) {
+		t.Errorf("tree = %q, want the regular file copied", got)
+	}
+}
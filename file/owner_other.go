@@ -0,0 +1,8 @@
+//go:build !unix
+
+package file
+
+import "io/fs"
+
+// lchown does nothing on platforms without Unix ownership.
+func lchown(string, fs.FileInfo) error { return nil }